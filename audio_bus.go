@@ -0,0 +1,304 @@
+package ge
+
+import (
+	"io"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// Predefined audio bus names.
+//
+// The default mixer layout looks like this:
+//
+//	master
+//	├── music
+//	├── sfx
+//	├── voice
+//	└── ui
+const (
+	AudioBusMaster = "master"
+	AudioBusMusic  = "music"
+	AudioBusSFX    = "sfx"
+	AudioBusVoice  = "voice"
+	AudioBusUI     = "ui"
+)
+
+// AudioBus is a mixer channel that controls the volume of all sounds routed through it.
+//
+// Buses form a tree: the resulting sound volume is a product
+// of all bus volumes on the path from the sound bus to the master bus.
+// Use AudioSystem.AddBus to create a new bus and AudioSystem.Bus to find an existing one.
+type AudioBus struct {
+	name string
+
+	parent   *AudioBus
+	children []*AudioBus
+
+	volume       float64
+	targetVolume float64
+	rampSpeed    float64
+
+	duck float64
+
+	muted bool
+	solo  bool
+
+	voices []busVoice
+
+	effects []AudioEffect
+
+	sys *AudioSystem
+}
+
+type busVoice struct {
+	player *audio.Player
+	volume float64
+}
+
+func newAudioBus(sys *AudioSystem, name string, parent *AudioBus) *AudioBus {
+	b := &AudioBus{
+		name:         name,
+		parent:       parent,
+		volume:       1,
+		targetVolume: 1,
+		duck:         1,
+		sys:          sys,
+	}
+	if parent != nil {
+		parent.children = append(parent.children, b)
+	}
+	return b
+}
+
+func (b *AudioBus) Name() string { return b.name }
+
+// Parent returns the parent bus.
+// It returns nil for the master bus.
+func (b *AudioBus) Parent() *AudioBus { return b.parent }
+
+// Children returns the buses that are routed into this bus.
+// The returned slice should not be modified.
+func (b *AudioBus) Children() []*AudioBus { return b.children }
+
+// Volume returns the current bus volume multiplier.
+// It can differ from the value passed to RampVolume while the ramp is in progress.
+func (b *AudioBus) Volume() float64 { return b.volume }
+
+// SetVolume changes the bus volume.
+// The playing sounds are affected starting from the next AudioSystem update.
+// It cancels the active volume ramp, if any.
+func (b *AudioBus) SetVolume(volume float64) {
+	b.volume = volume
+	b.targetVolume = volume
+	b.rampSpeed = 0
+}
+
+// RampVolume changes the bus volume smoothly over the specified duration (in seconds).
+func (b *AudioBus) RampVolume(volume, duration float64) {
+	if duration <= 0 {
+		b.SetVolume(volume)
+		return
+	}
+	b.targetVolume = volume
+	b.rampSpeed = math.Abs(volume-b.volume) / duration
+}
+
+func (b *AudioBus) IsMuted() bool { return b.muted }
+
+func (b *AudioBus) SetMuted(muted bool) {
+	b.muted = muted
+}
+
+func (b *AudioBus) IsSolo() bool { return b.solo }
+
+// SetSolo changes the bus solo mode.
+// When at least one bus is in the solo mode, only soloed buses
+// (and the buses routed into them) are audible.
+func (b *AudioBus) SetSolo(solo bool) {
+	if b.solo == solo {
+		return
+	}
+	b.solo = solo
+	if solo {
+		b.sys.numSoloBuses++
+	} else {
+		b.sys.numSoloBuses--
+	}
+}
+
+// AddEffect appends a DSP effect to the bus effects chain.
+//
+// The effects are applied to the sounds played via the PlaySound-like methods
+// and to the music tracks played via the PlayMusic-like methods.
+// Sounds of the child buses are processed by the parent buses effects too.
+// The changes affect only the sounds and the music tracks that are started after this call.
+func (b *AudioBus) AddEffect(e AudioEffect) {
+	b.effects = append(b.effects, e)
+	b.sys.effectsVersion++
+}
+
+// ClearEffects removes all effects from the bus effects chain.
+func (b *AudioBus) ClearEffects() {
+	b.effects = b.effects[:0]
+	b.sys.effectsVersion++
+}
+
+// Effects returns the bus effects chain.
+// The returned slice should not be modified.
+func (b *AudioBus) Effects() []AudioEffect { return b.effects }
+
+// hasEffects reports whether the bus or any of its parents has effects.
+func (b *AudioBus) hasEffects() bool {
+	for ; b != nil; b = b.parent {
+		if len(b.effects) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapEffects applies the effects chain of the bus and its parents to the src stream.
+func (b *AudioBus) wrapEffects(src io.ReadSeeker, sampleRate int) io.ReadSeeker {
+	for ; b != nil; b = b.parent {
+		for _, e := range b.effects {
+			src = e.Wrap(src, sampleRate)
+		}
+	}
+	return src
+}
+
+// EffectiveVolume returns the final bus volume multiplier that
+// takes the parent buses, mute, solo and ducking into account.
+func (b *AudioBus) EffectiveVolume() float64 {
+	if b.sys.numSoloBuses != 0 && !b.isSoloAudible() {
+		return 0
+	}
+	v := 1.0
+	for p := b; p != nil; p = p.parent {
+		if p.muted {
+			return 0
+		}
+		v *= p.volume * p.duck
+	}
+	return v
+}
+
+// IsActive reports whether any sound routed through this bus is playing.
+// Sounds of the child buses are taken into account too.
+func (b *AudioBus) IsActive() bool {
+	for _, v := range b.voices {
+		if v.player.IsPlaying() {
+			return true
+		}
+	}
+	for _, child := range b.children {
+		if child.IsActive() {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *AudioBus) isSoloAudible() bool {
+	for p := b; p != nil; p = p.parent {
+		if p.solo {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *AudioBus) addVoice(p *audio.Player, volume float64) {
+	p.SetVolume(volume * b.EffectiveVolume())
+	for i := range b.voices {
+		if b.voices[i].player == p {
+			b.voices[i].volume = volume
+			return
+		}
+	}
+	b.voices = append(b.voices, busVoice{player: p, volume: volume})
+}
+
+func (b *AudioBus) update(delta float64) {
+	if b.rampSpeed != 0 {
+		step := b.rampSpeed * delta
+		if math.Abs(b.targetVolume-b.volume) <= step {
+			b.volume = b.targetVolume
+			b.rampSpeed = 0
+		} else if b.targetVolume > b.volume {
+			b.volume += step
+		} else {
+			b.volume -= step
+		}
+	}
+
+	voices := b.voices[:0]
+	for _, v := range b.voices {
+		if v.player.IsPlaying() {
+			voices = append(voices, v)
+		}
+	}
+	b.voices = voices
+}
+
+func (b *AudioBus) applyVolume() {
+	if len(b.voices) == 0 {
+		return
+	}
+	multiplier := b.EffectiveVolume()
+	for _, v := range b.voices {
+		v.player.SetVolume(v.volume * multiplier)
+	}
+}
+
+// audioDucking lowers the target bus volume while the trigger bus is active.
+type audioDucking struct {
+	target  *AudioBus
+	trigger audioActivity
+
+	level   float64
+	attack  float64
+	release float64
+
+	current float64
+}
+
+// audioActivity is implemented by AudioBus.
+// It's an interface to make the ducking testable without the real audio players.
+type audioActivity interface {
+	IsActive() bool
+}
+
+func (d *audioDucking) update(delta float64) {
+	goal := 1.0
+	duration := d.release
+	if d.trigger.IsActive() {
+		goal = d.level
+		duration = d.attack
+	}
+	if d.current == goal {
+		return
+	}
+	step := (math.Abs(1-d.level) / duration) * delta
+	if duration <= 0 || math.Abs(goal-d.current) <= step {
+		d.current = goal
+	} else if goal > d.current {
+		d.current += step
+	} else {
+		d.current -= step
+	}
+}
+
+// AudioSettings is a serializable mixer state.
+//
+// It can be persisted with Context.SaveGameData and
+// restored with Context.LoadGameData + AudioSystem.ApplySettings.
+type AudioSettings struct {
+	Buses map[string]AudioBusSettings `json:"buses"`
+}
+
+type AudioBusSettings struct {
+	Volume float64 `json:"volume"`
+	Muted  bool    `json:"muted"`
+}
@@ -0,0 +1,134 @@
+package ge
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestAudioBusVolume(t *testing.T) {
+	var sys AudioSystem
+	sys.init(nil, nil, true)
+
+	music := sys.Bus(AudioBusMusic)
+	voice := sys.Bus(AudioBusVoice)
+	dialogue := sys.AddBus("dialogue", AudioBusVoice)
+
+	sys.MasterBus().SetVolume(0.5)
+	music.SetVolume(0.5)
+
+	checkVolume := func(step string, b *AudioBus, want float64) {
+		t.Helper()
+		if have := b.EffectiveVolume(); have != want {
+			t.Fatalf("%s: %s volume mismatch:\nhave: %v\nwant: %v", step, b.Name(), have, want)
+		}
+	}
+
+	checkVolume("initial", music, 0.25)
+	checkVolume("initial", dialogue, 0.5)
+
+	voice.SetSolo(true)
+	checkVolume("solo", music, 0)
+	checkVolume("solo", dialogue, 0.5)
+	voice.SetSolo(false)
+	checkVolume("unsolo", music, 0.25)
+
+	sys.MasterBus().SetMuted(true)
+	checkVolume("mute", music, 0)
+	checkVolume("mute", dialogue, 0)
+	sys.MasterBus().SetMuted(false)
+
+	music.RampVolume(1, 1)
+	sys.updateBuses(0.5)
+	checkVolume("ramp", music, 0.375)
+	sys.updateBuses(0.5)
+	checkVolume("ramp", music, 0.5)
+	sys.updateBuses(0.5)
+	checkVolume("ramp", music, 0.5)
+
+	settings := sys.Settings()
+	if s := settings.Buses[AudioBusMusic]; s.Volume != 1 || s.Muted {
+		t.Fatalf("unexpected music bus settings: %+v", s)
+	}
+	music.SetVolume(0)
+	sys.ApplySettings(settings)
+	checkVolume("restored", music, 0.5)
+}
+
+type fakeAudioActivity struct {
+	active bool
+}
+
+func (a *fakeAudioActivity) IsActive() bool { return a.active }
+
+func TestAudioBusDucking(t *testing.T) {
+	var sys AudioSystem
+	sys.init(nil, nil, true)
+
+	sys.AddDucking(AudioBusMusic, AudioBusVoice, 0.25, 0.5, 1)
+	trigger := &fakeAudioActivity{}
+	sys.duckings[0].trigger = trigger
+	music := sys.Bus(AudioBusMusic)
+
+	steps := []struct {
+		active bool
+		delta  float64
+		want   float64
+	}{
+		{false, 0.5, 1},
+		{true, 0.25, 0.625},
+		{true, 0.25, 0.25},
+		{true, 0.25, 0.25},
+		{false, 0.5, 0.625},
+		{false, 0.5, 1},
+		{false, 0.5, 1},
+	}
+	for i, step := range steps {
+		trigger.active = step.active
+		sys.updateBuses(step.delta)
+		if have := music.EffectiveVolume(); have != step.want {
+			t.Fatalf("step %d: music volume mismatch:\nhave: %v\nwant: %v", i, have, step.want)
+		}
+	}
+
+	if have := sys.Bus(AudioBusVoice).EffectiveVolume(); have != 1 {
+		t.Fatalf("trigger bus volume is affected: %v", have)
+	}
+}
+
+type testAudioEffect struct {
+	name    string
+	wrapped *[]string
+}
+
+func (e testAudioEffect) Wrap(src io.ReadSeeker, sampleRate int) io.ReadSeeker {
+	*e.wrapped = append(*e.wrapped, e.name)
+	return src
+}
+
+func TestAudioBusEffectsChain(t *testing.T) {
+	var sys AudioSystem
+	sys.init(nil, nil, true)
+
+	music := sys.Bus(AudioBusMusic)
+	sfx := sys.Bus(AudioBusSFX)
+	if music.hasEffects() || sfx.hasEffects() {
+		t.Fatal("the buses should have no effects by default")
+	}
+
+	var wrapped []string
+	music.AddEffect(testAudioEffect{name: "lowpass", wrapped: &wrapped})
+	if !music.hasEffects() || sfx.hasEffects() {
+		t.Fatal("only the music bus should have the effects")
+	}
+	sys.MasterBus().AddEffect(testAudioEffect{name: "reverb", wrapped: &wrapped})
+	if !sfx.hasEffects() {
+		t.Fatal("the master bus effects should be applied to its children")
+	}
+
+	music.wrapEffects(bytes.NewReader(nil), 44100)
+	if fmt.Sprint(wrapped) != "[lowpass reverb]" {
+		t.Fatalf("unexpected effects order: %v", wrapped)
+	}
+}
@@ -0,0 +1,417 @@
+package ge
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"sync/atomic"
+)
+
+// AudioEffect is a DSP effect that can be applied to a 16-bit stereo PCM stream,
+// like the ones returned by AudioSystem.DecodeWAV and AudioSystem.DecodeOGG.
+//
+// Effects can be attached to the audio buses (see AudioBus.AddEffect)
+// or used directly to process the decoded streams.
+type AudioEffect interface {
+	// Wrap returns a stream that applies the effect to the src stream.
+	// The returned stream is seekable; seeking resets the effect state.
+	Wrap(src io.ReadSeeker, sampleRate int) io.ReadSeeker
+}
+
+// LowPassEffect is a one-pole low-pass filter.
+// It attenuates the frequencies above the Cutoff (in Hz), muffling the sound.
+type LowPassEffect struct {
+	Cutoff float64
+}
+
+func (e LowPassEffect) Wrap(src io.ReadSeeker, sampleRate int) io.ReadSeeker {
+	a := onePoleCoefficient(e.Cutoff, sampleRate)
+	var yl, yr float64
+	return &pcmStream{
+		src: src,
+		process: func(l, r float64) (float64, float64) {
+			yl += a * (l - yl)
+			yr += a * (r - yr)
+			return yl, yr
+		},
+		reset: func() { yl, yr = 0, 0 },
+	}
+}
+
+// HighPassEffect is a one-pole high-pass filter.
+// It attenuates the frequencies below the Cutoff (in Hz), making the sound thinner.
+type HighPassEffect struct {
+	Cutoff float64
+}
+
+func (e HighPassEffect) Wrap(src io.ReadSeeker, sampleRate int) io.ReadSeeker {
+	a := onePoleCoefficient(e.Cutoff, sampleRate)
+	var yl, yr float64
+	return &pcmStream{
+		src: src,
+		process: func(l, r float64) (float64, float64) {
+			yl += a * (l - yl)
+			yr += a * (r - yr)
+			return l - yl, r - yr
+		},
+		reset: func() { yl, yr = 0, 0 },
+	}
+}
+
+// EchoEffect repeats the sound after the Delay (in seconds).
+//
+// Feedback controls how much of the echo is fed back into the delay line,
+// Mix is the echo volume relative to the original sound.
+type EchoEffect struct {
+	Delay    float64
+	Feedback float64
+	Mix      float64
+}
+
+func (e EchoEffect) Wrap(src io.ReadSeeker, sampleRate int) io.ReadSeeker {
+	delayFrames := int(e.Delay * float64(sampleRate))
+	if delayFrames < 1 {
+		delayFrames = 1
+	}
+	bufL := make([]float64, delayFrames)
+	bufR := make([]float64, delayFrames)
+	i := 0
+	return &pcmStream{
+		src:  src,
+		tail: delayFrames * echoRepeats(e.Feedback),
+		process: func(l, r float64) (float64, float64) {
+			dl := bufL[i]
+			dr := bufR[i]
+			bufL[i] = l + dl*e.Feedback
+			bufR[i] = r + dr*e.Feedback
+			i = (i + 1) % delayFrames
+			return l + dl*e.Mix, r + dr*e.Mix
+		},
+		reset: func() {
+			for j := range bufL {
+				bufL[j] = 0
+				bufR[j] = 0
+			}
+			i = 0
+		},
+	}
+}
+
+// ReverbEffect is a simple Schroeder-style reverberation.
+//
+// RoomSize (0-1) controls the reverb decay time,
+// Damping (0-1) makes the reflections darker, Mix is the reverb volume.
+type ReverbEffect struct {
+	RoomSize float64
+	Damping  float64
+	Mix      float64
+}
+
+func (e ReverbEffect) Wrap(src io.ReadSeeker, sampleRate int) io.ReadSeeker {
+	left := newReverbChannel(sampleRate, 0, e)
+	right := newReverbChannel(sampleRate, 23, e)
+	return &pcmStream{
+		src:  src,
+		tail: sampleRate * 2,
+		process: func(l, r float64) (float64, float64) {
+			return l + left.process(l)*e.Mix, r + right.process(r)*e.Mix
+		},
+		reset: func() {
+			left.reset()
+			right.reset()
+		},
+	}
+}
+
+type reverbChannel struct {
+	combs     [4]combFilter
+	allpasses [2]allpassFilter
+}
+
+func newReverbChannel(sampleRate, spread int, e ReverbEffect) *reverbChannel {
+	// The classic Freeverb delay lengths, tuned for 44100Hz.
+	combLengths := [4]int{1116, 1188, 1277, 1356}
+	allpassLengths := [2]int{556, 441}
+	scale := float64(sampleRate) / 44100
+	ch := &reverbChannel{}
+	feedback := 0.7 + 0.28*e.RoomSize
+	for i, n := range combLengths {
+		ch.combs[i] = combFilter{
+			buf:      make([]float64, int(float64(n+spread)*scale)+1),
+			feedback: feedback,
+			damping:  e.Damping,
+		}
+	}
+	for i, n := range allpassLengths {
+		ch.allpasses[i] = allpassFilter{
+			buf: make([]float64, int(float64(n+spread)*scale)+1),
+		}
+	}
+	return ch
+}
+
+func (ch *reverbChannel) process(x float64) float64 {
+	out := 0.0
+	for i := range ch.combs {
+		out += ch.combs[i].process(x)
+	}
+	out /= float64(len(ch.combs))
+	for i := range ch.allpasses {
+		out = ch.allpasses[i].process(out)
+	}
+	return out
+}
+
+func (ch *reverbChannel) reset() {
+	for i := range ch.combs {
+		ch.combs[i].reset()
+	}
+	for i := range ch.allpasses {
+		ch.allpasses[i].reset()
+	}
+}
+
+type combFilter struct {
+	buf      []float64
+	i        int
+	feedback float64
+	damping  float64
+	store    float64
+}
+
+func (f *combFilter) process(x float64) float64 {
+	y := f.buf[f.i]
+	f.store = y*(1-f.damping) + f.store*f.damping
+	f.buf[f.i] = x + f.store*f.feedback
+	f.i = (f.i + 1) % len(f.buf)
+	return y
+}
+
+func (f *combFilter) reset() {
+	for i := range f.buf {
+		f.buf[i] = 0
+	}
+	f.i = 0
+	f.store = 0
+}
+
+type allpassFilter struct {
+	buf []float64
+	i   int
+}
+
+func (f *allpassFilter) process(x float64) float64 {
+	const feedback = 0.5
+	delayed := f.buf[f.i]
+	f.buf[f.i] = x + delayed*feedback
+	f.i = (f.i + 1) % len(f.buf)
+	return delayed - x
+}
+
+func (f *allpassFilter) reset() {
+	for i := range f.buf {
+		f.buf[i] = 0
+	}
+	f.i = 0
+}
+
+func onePoleCoefficient(cutoff float64, sampleRate int) float64 {
+	return 1 - math.Exp(-2*math.Pi*cutoff/float64(sampleRate))
+}
+
+// echoRepeats returns the number of echo repeats before it becomes inaudible.
+func echoRepeats(feedback float64) int {
+	const maxRepeats = 16
+	if feedback <= 0 {
+		return 1
+	}
+	if feedback >= 1 {
+		return maxRepeats
+	}
+	n := int(math.Ceil(math.Log(0.01)/math.Log(feedback))) + 1
+	if n > maxRepeats {
+		return maxRepeats
+	}
+	return n
+}
+
+// pcmStream applies a per-frame processing function to a 16-bit stereo PCM stream.
+// The samples are passed to the process func in [-1, 1] range.
+type pcmStream struct {
+	src io.ReadSeeker
+
+	process func(l, r float64) (float64, float64)
+	reset   func()
+
+	// tail is a number of extra frames that are produced after
+	// the src stream ends, so the effects like echo can fade out.
+	tail      int
+	tailFrame int
+	srcEOF    bool
+}
+
+func (s *pcmStream) Seek(offset int64, whence int) (int64, error) {
+	s.reset()
+	s.srcEOF = false
+	s.tailFrame = 0
+	return s.src.Seek(offset, whence)
+}
+
+func (s *pcmStream) Read(p []byte) (int, error) {
+	p = p[:len(p)&^(bytesPerSample-1)]
+
+	n := 0
+	if !s.srcEOF {
+		var err error
+		n, err = io.ReadFull(s.src, p)
+		n &^= bytesPerSample - 1
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			s.srcEOF = true
+		default:
+			return 0, err
+		}
+	}
+	if s.srcEOF {
+		// Fill the rest of the buffer with the silence tail.
+		for n < len(p) && s.tailFrame < s.tail {
+			copy(p[n:n+bytesPerSample], []byte{0, 0, 0, 0})
+			n += bytesPerSample
+			s.tailFrame++
+		}
+	}
+
+	for i := 0; i < n; i += bytesPerSample {
+		l := float64(int16(binary.LittleEndian.Uint16(p[i:]))) / math.MaxInt16
+		r := float64(int16(binary.LittleEndian.Uint16(p[i+2:]))) / math.MaxInt16
+		l, r = s.process(l, r)
+		binary.LittleEndian.PutUint16(p[i:], uint16(pcmSample(l)))
+		binary.LittleEndian.PutUint16(p[i+2:], uint16(pcmSample(r)))
+	}
+
+	if n == 0 && s.srcEOF {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+func pcmSample(v float64) int16 {
+	v *= math.MaxInt16
+	if v > math.MaxInt16 {
+		return math.MaxInt16
+	}
+	if v < math.MinInt16 {
+		return math.MinInt16
+	}
+	return int16(v)
+}
+
+// pitchStream changes the playback speed of a 16-bit stereo PCM stream
+// using the linear interpolation, so the pitch is changed as well.
+type pitchStream struct {
+	src io.ReadSeeker
+
+	// pitch is accessed from the audio goroutine,
+	// so it's stored as atomic float64 bits.
+	pitch uint64
+
+	buf    []byte
+	bufPos int
+	bufLen int
+
+	prev [2]float64
+	next [2]float64
+	frac float64
+
+	srcEOF bool
+	eof    bool
+}
+
+func newPitchStream(src io.ReadSeeker) *pitchStream {
+	s := &pitchStream{
+		src: src,
+		buf: make([]byte, 4096),
+	}
+	s.SetPitch(1)
+	s.resetState()
+	return s
+}
+
+func (s *pitchStream) SetPitch(pitch float64) {
+	atomic.StoreUint64(&s.pitch, math.Float64bits(pitch))
+}
+
+func (s *pitchStream) resetState() {
+	s.bufPos = 0
+	s.bufLen = 0
+	s.prev = [2]float64{}
+	s.next = [2]float64{}
+	s.frac = 2 // Forces the first two frames to be read
+	s.srcEOF = false
+	s.eof = false
+}
+
+func (s *pitchStream) Seek(offset int64, whence int) (int64, error) {
+	s.resetState()
+	return s.src.Seek(offset, whence)
+}
+
+func (s *pitchStream) readFrame() bool {
+	if s.bufPos+bytesPerSample > s.bufLen {
+		n, err := io.ReadFull(s.src, s.buf)
+		n &^= bytesPerSample - 1
+		s.bufPos = 0
+		s.bufLen = n
+		if n == 0 || (err != nil && err != io.ErrUnexpectedEOF) {
+			if s.srcEOF {
+				return false
+			}
+			// Emit the last frame before reporting the EOF.
+			s.srcEOF = true
+			s.prev = s.next
+			return true
+		}
+	}
+	s.prev = s.next
+	s.next[0] = float64(int16(binary.LittleEndian.Uint16(s.buf[s.bufPos:])))
+	s.next[1] = float64(int16(binary.LittleEndian.Uint16(s.buf[s.bufPos+2:])))
+	s.bufPos += bytesPerSample
+	return true
+}
+
+func (s *pitchStream) Read(p []byte) (int, error) {
+	if s.eof {
+		return 0, io.EOF
+	}
+	pitch := math.Float64frombits(atomic.LoadUint64(&s.pitch))
+	if pitch <= 0 {
+		pitch = 1
+	}
+
+	p = p[:len(p)&^(bytesPerSample-1)]
+	n := 0
+	for n < len(p) {
+		for s.frac >= 1 {
+			if !s.readFrame() {
+				s.eof = true
+				break
+			}
+			s.frac--
+		}
+		if s.eof {
+			break
+		}
+		l := s.prev[0] + (s.next[0]-s.prev[0])*s.frac
+		r := s.prev[1] + (s.next[1]-s.prev[1])*s.frac
+		binary.LittleEndian.PutUint16(p[n:], uint16(int16(l)))
+		binary.LittleEndian.PutUint16(p[n+2:], uint16(int16(r)))
+		n += bytesPerSample
+		s.frac += pitch
+	}
+
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
@@ -0,0 +1,72 @@
+package ge
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func encodePCM(samples []int16) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, samples)
+	return buf.Bytes()
+}
+
+func decodePCM(t *testing.T, r io.Reader) []int16 {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples := make([]int16, len(data)/2)
+	binary.Read(bytes.NewReader(data), binary.LittleEndian, samples)
+	return samples
+}
+
+func TestPitchStream(t *testing.T) {
+	data := encodePCM([]int16{0, 0, 100, -100, 200, -200, 300, -300, 400, -400})
+
+	tests := []struct {
+		pitch float64
+		want  []int16
+	}{
+		{1, []int16{0, 0, 100, -100, 200, -200, 300, -300, 400, -400}},
+		{2, []int16{0, 0, 200, -200, 400, -400}},
+		{0.5, []int16{0, 0, 50, -50, 100, -100, 150, -150, 200, -200, 250, -250, 300, -300, 350, -350, 400, -400, 400, -400}},
+	}
+
+	for _, test := range tests {
+		s := newPitchStream(bytes.NewReader(data))
+		s.SetPitch(test.pitch)
+		for round := 0; round < 2; round++ {
+			if _, err := s.Seek(0, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+			have := decodePCM(t, s)
+			if len(have) != len(test.want) {
+				t.Fatalf("pitch=%v:\nhave: %v\nwant: %v", test.pitch, have, test.want)
+			}
+			for i := range have {
+				if have[i] != test.want[i] {
+					t.Fatalf("pitch=%v:\nhave: %v\nwant: %v", test.pitch, have, test.want)
+				}
+			}
+		}
+	}
+}
+
+func TestEchoEffect(t *testing.T) {
+	data := encodePCM([]int16{1000, 1000, 0, 0})
+	e := EchoEffect{Delay: 2.0 / 10, Feedback: 0.5, Mix: 1}
+	s := e.Wrap(bytes.NewReader(data), 10)
+	have := decodePCM(t, s)
+	want := []int16{1000, 1000, 0, 0, 1000, 1000, 0, 0, 500, 500, 0, 0}
+	if len(have) < len(want) {
+		t.Fatalf("the echo tail is too short: %v", have)
+	}
+	for i := range want {
+		if d := int(have[i]) - int(want[i]); d < -1 || d > 1 {
+			t.Fatalf("have: %v\nwant: %v", have[:len(want)], want)
+		}
+	}
+}
@@ -0,0 +1,489 @@
+package ge
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/gmath"
+)
+
+// bytesPerSample is a size of a single decoded stereo 16-bit PCM frame.
+const bytesPerSample = 4
+
+// MusicLoop describes the track sections for the seamless looping.
+//
+// The track is played from the beginning, then the loop section
+// is repeated infinitely. All values are measured in samples (PCM frames)
+// at the audio context sample rate, so the loop points are sample-accurate.
+type MusicLoop struct {
+	// IntroSamples is the intro section length.
+	// Zero means that the loop starts at the beginning of the track.
+	IntroSamples int64
+
+	// LoopSamples is the loop section length.
+	// Zero means that the loop lasts until the end of the track.
+	LoopSamples int64
+}
+
+// byteRange converts the loop sections into the PCM byte offsets
+// that are used by the intro+loop audio stream.
+func (loop MusicLoop) byteRange(dataLength int64) (introLength, loopLength int64) {
+	introLength = loop.IntroSamples * bytesPerSample
+	loopLength = loop.LoopSamples * bytesPerSample
+	if loopLength == 0 {
+		loopLength = dataLength - introLength
+	}
+	return introLength, loopLength
+}
+
+// pcmDuration returns the playback duration of the decoded PCM data.
+func pcmDuration(dataLength int64, sampleRate int) time.Duration {
+	numSamples := dataLength / bytesPerSample
+	return time.Duration(numSamples) * time.Second / time.Duration(sampleRate)
+}
+
+// MusicPlaylist is a sequence of music tracks that are played one after another.
+type MusicPlaylist struct {
+	Tracks []resource.AudioID
+
+	// Shuffle randomizes the tracks order.
+	// The order is randomized again on every repeat.
+	Shuffle bool
+
+	// Rand is used for the shuffling.
+	// It's required if Shuffle is true.
+	Rand *gmath.Rand
+
+	// Repeat makes the playlist start over after the last track.
+	Repeat bool
+
+	// Crossfade is a duration of the tracks transition (in seconds).
+	// Zero means that the next track starts right after the previous one ends.
+	Crossfade float64
+}
+
+type musicState struct {
+	tracks  []*musicTrack
+	current *musicTrack
+	paused  bool
+
+	loops map[resource.AudioID]MusicLoop
+	cache map[musicCacheKey]musicSource
+
+	playlist      *MusicPlaylist
+	playlistOrder []int
+	playlistIndex int
+}
+
+type musicCacheKey struct {
+	id     resource.AudioID
+	looped bool
+}
+
+type musicSource struct {
+	res    resource.Audio
+	length time.Duration
+
+	// effectsVersion is used to re-create the player
+	// when the bus effects chain changes.
+	effectsVersion int
+}
+
+type musicTrack struct {
+	musicSource
+
+	fade       float64
+	fadeTarget float64
+	fadeSpeed  float64
+
+	// paused is set for the tracks that were playing
+	// when the music was paused, so they can be resumed.
+	paused bool
+}
+
+func (t *musicTrack) fadeTo(target, duration float64) {
+	t.fadeTarget = target
+	if duration <= 0 {
+		t.fade = target
+		t.fadeSpeed = 0
+		return
+	}
+	t.fadeSpeed = 1 / duration
+}
+
+func (t *musicTrack) update(delta float64) {
+	if t.fade == t.fadeTarget {
+		return
+	}
+	step := t.fadeSpeed * delta
+	switch {
+	case t.fadeSpeed == 0 || math.Abs(t.fadeTarget-t.fade) <= step:
+		t.fade = t.fadeTarget
+	case t.fadeTarget > t.fade:
+		t.fade += step
+	default:
+		t.fade -= step
+	}
+}
+
+// SetMusicLoop makes the music track use the intro+loop playback mode.
+// It should be called before the track is played for the first time.
+func (sys *AudioSystem) SetMusicLoop(id resource.AudioID, loop MusicLoop) {
+	if sys.music.loops == nil {
+		sys.music.loops = make(map[resource.AudioID]MusicLoop)
+	}
+	sys.music.loops[id] = loop
+}
+
+func (sys *AudioSystem) MusicIsPlaying() bool {
+	if sys.currentMusic.Player == nil {
+		return false
+	}
+	return sys.currentMusic.Player.IsPlaying()
+}
+
+// PauseCurrentMusic pauses the music playback.
+// The tracks that are being crossfaded are paused too, their fades are frozen.
+func (sys *AudioSystem) PauseCurrentMusic() {
+	if sys.muted {
+		return
+	}
+	if sys.currentMusic.Player == nil {
+		return
+	}
+	sys.music.paused = true
+	for _, t := range sys.music.tracks {
+		if t.res.Player.IsPlaying() {
+			t.paused = true
+			t.res.Player.Pause()
+		}
+	}
+	sys.currentMusic.Player.Pause()
+}
+
+// ContinueCurrentMusic resumes the music paused by PauseCurrentMusic.
+func (sys *AudioSystem) ContinueCurrentMusic() {
+	if sys.muted {
+		return
+	}
+	if sys.currentMusic.Player == nil || sys.currentMusic.Player.IsPlaying() {
+		return
+	}
+	sys.music.paused = false
+	for _, t := range sys.music.tracks {
+		if t.paused {
+			t.paused = false
+			sys.playMusicPlayer(t.res)
+		}
+	}
+	if !sys.currentMusic.Player.IsPlaying() {
+		sys.playMusicPlayer(sys.currentMusic)
+	}
+}
+
+// ContinueMusic plays the music track from the position it was paused at.
+// Other tracks are stopped.
+func (sys *AudioSystem) ContinueMusic(id resource.AudioID) {
+	if sys.muted {
+		return
+	}
+	src := sys.loadMusic(id, true)
+	if src.res.Player.IsPlaying() {
+		return
+	}
+	sys.startMusic(src, 0, false)
+}
+
+// PlayMusic starts the music track from the beginning.
+// Other tracks are stopped.
+//
+// It does nothing if this track is already playing.
+func (sys *AudioSystem) PlayMusic(id resource.AudioID) {
+	sys.CrossfadeMusic(id, 0)
+}
+
+// CrossfadeMusic starts the music track from the beginning while
+// fading out the currently playing track over the specified duration (in seconds).
+//
+// It does nothing if this track is already playing.
+func (sys *AudioSystem) CrossfadeMusic(id resource.AudioID, duration float64) {
+	if sys.muted {
+		return
+	}
+	sys.music.playlist = nil
+	src := sys.loadMusic(id, true)
+	if sys.currentMusic.Player != nil && src.res.Player == sys.currentMusic.Player && src.res.Player.IsPlaying() {
+		return
+	}
+	sys.startMusic(src, duration, true)
+}
+
+// FadeInMusic starts the music track from the beginning, rising its volume from the silence.
+// Unlike CrossfadeMusic, the other tracks are stopped immediately.
+func (sys *AudioSystem) FadeInMusic(id resource.AudioID, duration float64) {
+	if sys.muted {
+		return
+	}
+	sys.music.playlist = nil
+	src := sys.loadMusic(id, true)
+	for _, t := range sys.music.tracks {
+		if t.res.Player != src.res.Player {
+			t.fadeTo(0, 0)
+		}
+	}
+	sys.startMusic(src, duration, true)
+}
+
+// FadeOutMusic stops all playing music tracks by fading them out
+// over the specified duration (in seconds).
+// The active playlist is stopped as well.
+func (sys *AudioSystem) FadeOutMusic(duration float64) {
+	if sys.muted {
+		return
+	}
+	sys.music.playlist = nil
+	for _, t := range sys.music.tracks {
+		t.fadeTo(0, duration)
+	}
+}
+
+// PlayPlaylist starts playing the playlist tracks.
+// The currently playing track is crossfaded with the first playlist track.
+func (sys *AudioSystem) PlayPlaylist(p *MusicPlaylist) {
+	if sys.muted || len(p.Tracks) == 0 {
+		return
+	}
+	sys.music.playlist = p
+	sys.music.playlistIndex = -1
+	sys.resetPlaylistOrder()
+	sys.NextTrack()
+}
+
+// StopPlaylist stops the playlist progression.
+// The current track continues to play until its end.
+func (sys *AudioSystem) StopPlaylist() {
+	sys.music.playlist = nil
+}
+
+// NextTrack switches to the next playlist track.
+// It does nothing if there is no active playlist.
+func (sys *AudioSystem) NextTrack() {
+	id, ok := sys.advancePlaylist()
+	if !ok {
+		return
+	}
+	sys.startMusic(sys.loadMusic(id, false), sys.music.playlist.Crossfade, true)
+}
+
+// advancePlaylist moves to the next playlist track and returns its ID.
+// The playlist is deactivated when it's over.
+func (sys *AudioSystem) advancePlaylist() (resource.AudioID, bool) {
+	p := sys.music.playlist
+	if p == nil {
+		return 0, false
+	}
+	sys.music.playlistIndex++
+	if sys.music.playlistIndex >= len(sys.music.playlistOrder) {
+		if !p.Repeat {
+			sys.music.playlist = nil
+			return 0, false
+		}
+		sys.resetPlaylistOrder()
+		sys.music.playlistIndex = 0
+	}
+	return p.Tracks[sys.music.playlistOrder[sys.music.playlistIndex]], true
+}
+
+func (sys *AudioSystem) resetPlaylistOrder() {
+	p := sys.music.playlist
+	order := sys.music.playlistOrder[:0]
+	for i := range p.Tracks {
+		order = append(order, i)
+	}
+	if p.Shuffle {
+		gmath.Shuffle(p.Rand, order)
+	}
+	sys.music.playlistOrder = order
+}
+
+func (sys *AudioSystem) startMusic(src musicSource, fadeDuration float64, rewind bool) {
+	var track *musicTrack
+	for _, t := range sys.music.tracks {
+		if t.res.Player == src.res.Player {
+			track = t
+			continue
+		}
+		t.fadeTo(0, fadeDuration)
+	}
+	if track == nil {
+		track = &musicTrack{musicSource: src}
+		sys.music.tracks = append(sys.music.tracks, track)
+	}
+	track.paused = false
+	if fadeDuration > 0 && (rewind || !track.res.Player.IsPlaying()) {
+		track.fade = 0
+	}
+	track.fadeTo(1, fadeDuration)
+
+	if rewind {
+		track.res.Player.Rewind()
+	}
+	sys.music.current = track
+	sys.music.paused = false
+	sys.currentMusic = src.res
+	sys.playMusicPlayer(src.res)
+	sys.updateMusic(0)
+}
+
+func (sys *AudioSystem) playMusicPlayer(res resource.Audio) {
+	b := sys.busFor(res, AudioBusMusic)
+	b.addVoice(res.Player, res.Volume*sys.getGroupVolume(res.Group)*sys.musicFade(res))
+	res.Player.Play()
+}
+
+func (sys *AudioSystem) musicFade(res resource.Audio) float64 {
+	for _, t := range sys.music.tracks {
+		if t.res.Player == res.Player {
+			return t.fade
+		}
+	}
+	return 1
+}
+
+func (sys *AudioSystem) updateMusic(delta float64) {
+	tracks := sys.music.tracks[:0]
+	for _, t := range sys.music.tracks {
+		if !sys.music.paused {
+			// The fades are frozen while the music is paused.
+			t.update(delta)
+		}
+		if t.fade == 0 && t.fadeTarget == 0 {
+			t.res.Player.Pause()
+			if t == sys.music.current {
+				sys.music.current = nil
+			}
+			continue
+		}
+		if t.res.Player.IsPlaying() {
+			b := sys.busFor(t.res, AudioBusMusic)
+			b.addVoice(t.res.Player, t.res.Volume*sys.getGroupVolume(t.res.Group)*t.fade)
+		}
+		tracks = append(tracks, t)
+	}
+	sys.music.tracks = tracks
+
+	p := sys.music.playlist
+	current := sys.music.current
+	if p == nil || current == nil || sys.music.paused || delta == 0 {
+		return
+	}
+	player := current.res.Player
+	ended := !player.IsPlaying()
+	nearEnd := p.Crossfade > 0 &&
+		current.length > 0 &&
+		(current.length-player.Position()).Seconds() <= p.Crossfade
+	if ended || nearEnd {
+		sys.NextTrack()
+	}
+}
+
+// loadMusic returns the music track player.
+//
+// The looped tracks are played infinitely (using the intro+loop mode if
+// it was configured for this track). Other tracks stop at their end.
+func (sys *AudioSystem) loadMusic(id resource.AudioID, looped bool) musicSource {
+	info := sys.loader.GetAudioInfo(id)
+	bus := sys.busFor(resource.Audio{Group: info.Group}, AudioBusMusic)
+	_, hasLoop := sys.music.loops[id]
+	if looped && !hasLoop && !bus.hasEffects() {
+		// The default looped music playback is provided by the loader.
+		return musicSource{res: sys.loader.LoadAudio(id)}
+	}
+
+	key := musicCacheKey{id: id, looped: looped}
+	cached, ok := sys.music.cache[key]
+	if ok {
+		// The playing track keeps its effects until it's stopped.
+		if cached.effectsVersion == sys.effectsVersion || cached.res.Player.IsPlaying() {
+			return cached
+		}
+		if !sys.musicPlayerIsUsed(cached.res.Player) {
+			cached.res.Player.Close()
+		}
+	}
+
+	data := sys.decodeAudio(info.Path)
+	var src musicSource
+	src.effectsVersion = sys.effectsVersion
+	src.res = resource.Audio{
+		ID:     id,
+		Volume: (info.Volume / 2) + 0.5,
+		Group:  info.Group,
+	}
+	var stream io.ReadSeeker
+	if looped {
+		introLength, loopLength := sys.music.loops[id].byteRange(int64(len(data)))
+		stream = audio.NewInfiniteLoopWithIntro(bytes.NewReader(data), introLength, loopLength)
+	} else {
+		stream = bytes.NewReader(data)
+		src.length = pcmDuration(int64(len(data)), sys.audioContext.SampleRate())
+	}
+	// The effects are applied after the looping, so their state is continuous.
+	stream = bus.wrapEffects(stream, sys.audioContext.SampleRate())
+	player, err := sys.audioContext.NewPlayer(stream)
+	if err != nil {
+		panic(fmt.Sprintf("create %q player: %v", info.Path, err))
+	}
+	src.res.Player = player
+
+	if sys.music.cache == nil {
+		sys.music.cache = make(map[musicCacheKey]musicSource)
+	}
+	sys.music.cache[key] = src
+	return src
+}
+
+func (sys *AudioSystem) musicPlayerIsUsed(player *audio.Player) bool {
+	if sys.currentMusic.Player == player {
+		return true
+	}
+	for _, t := range sys.music.tracks {
+		if t.res.Player == player {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeAudio reads the entire WAV or OGG asset into a PCM bytes slice.
+func (sys *AudioSystem) decodeAudio(path string) []byte {
+	r := sys.loader.OpenAssetFunc(path)
+	defer func() {
+		if err := r.Close(); err != nil {
+			panic(fmt.Sprintf("closing %q reader: %v", path, err))
+		}
+	}()
+	var stream io.Reader
+	var err error
+	if strings.HasSuffix(path, ".ogg") {
+		stream, err = vorbis.DecodeWithSampleRate(sys.audioContext.SampleRate(), r)
+	} else {
+		stream, err = wav.DecodeWithSampleRate(sys.audioContext.SampleRate(), r)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("decode %q: %v", path, err))
+	}
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		panic(fmt.Sprintf("read %q: %v", path, err))
+	}
+	return data
+}
@@ -0,0 +1,151 @@
+package ge
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/gmath"
+)
+
+func TestMusicLoopByteRange(t *testing.T) {
+	tests := []struct {
+		loop       MusicLoop
+		dataLength int64
+		intro      int64
+		length     int64
+	}{
+		{MusicLoop{}, 400, 0, 400},
+		{MusicLoop{IntroSamples: 10}, 400, 40, 360},
+		{MusicLoop{LoopSamples: 50}, 400, 0, 200},
+		{MusicLoop{IntroSamples: 10, LoopSamples: 50}, 400, 40, 200},
+		{MusicLoop{IntroSamples: 100}, 400, 400, 0},
+	}
+
+	for _, test := range tests {
+		intro, length := test.loop.byteRange(test.dataLength)
+		if intro != test.intro || length != test.length {
+			t.Errorf("%+v.byteRange(%d):\nhave: %d, %d\nwant: %d, %d",
+				test.loop, test.dataLength, intro, length, test.intro, test.length)
+		}
+	}
+}
+
+func TestPCMDuration(t *testing.T) {
+	tests := []struct {
+		dataLength int64
+		sampleRate int
+		want       time.Duration
+	}{
+		{0, 44100, 0},
+		{44100 * bytesPerSample, 44100, time.Second},
+		{22050 * bytesPerSample, 44100, 500 * time.Millisecond},
+		{48000 * bytesPerSample * 3, 48000, 3 * time.Second},
+		// A partial PCM frame is not counted.
+		{44100*bytesPerSample + 3, 44100, time.Second},
+	}
+
+	for _, test := range tests {
+		have := pcmDuration(test.dataLength, test.sampleRate)
+		if have != test.want {
+			t.Errorf("pcmDuration(%d, %d):\nhave: %v\nwant: %v", test.dataLength, test.sampleRate, have, test.want)
+		}
+	}
+}
+
+func TestMusicTrackFade(t *testing.T) {
+	track := &musicTrack{fade: 1, fadeTarget: 1}
+	track.fadeTo(0, 2)
+
+	for i, want := range []float64{0.75, 0.5, 0.25, 0, 0} {
+		track.update(0.5)
+		if track.fade != want {
+			t.Fatalf("step %d: fade mismatch:\nhave: %v\nwant: %v", i, track.fade, want)
+		}
+	}
+
+	track.fadeTo(1, 0)
+	if track.fade != 1 {
+		t.Fatalf("instant fade mismatch:\nhave: %v\nwant: 1", track.fade)
+	}
+}
+
+func collectPlaylist(sys *AudioSystem, p *MusicPlaylist, n int) []resource.AudioID {
+	sys.music.playlist = p
+	sys.music.playlistIndex = -1
+	sys.resetPlaylistOrder()
+	var ids []resource.AudioID
+	for i := 0; i < n; i++ {
+		id, ok := sys.advancePlaylist()
+		if !ok {
+			break
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestPlaylistOrder(t *testing.T) {
+	var sys AudioSystem
+
+	p := &MusicPlaylist{Tracks: []resource.AudioID{10, 20, 30}}
+	have := collectPlaylist(&sys, p, 10)
+	want := []resource.AudioID{10, 20, 30}
+	if !equalAudioIDs(have, want) {
+		t.Fatalf("sequential playlist:\nhave: %v\nwant: %v", have, want)
+	}
+	if sys.music.playlist != nil {
+		t.Fatalf("the finished playlist is still active")
+	}
+
+	p.Repeat = true
+	have = collectPlaylist(&sys, p, 7)
+	want = []resource.AudioID{10, 20, 30, 10, 20, 30, 10}
+	if !equalAudioIDs(have, want) {
+		t.Fatalf("repeated playlist:\nhave: %v\nwant: %v", have, want)
+	}
+}
+
+func TestPlaylistShuffle(t *testing.T) {
+	var sys AudioSystem
+
+	tracks := []resource.AudioID{1, 2, 3, 4, 5, 6, 7, 8}
+	var rng gmath.Rand
+	rng.SetSeed(1)
+	p := &MusicPlaylist{Tracks: tracks, Shuffle: true, Repeat: true, Rand: &rng}
+
+	const numRounds = 4
+	ids := collectPlaylist(&sys, p, len(tracks)*numRounds)
+	if len(ids) != len(tracks)*numRounds {
+		t.Fatalf("expected %d tracks, got %d", len(tracks)*numRounds, len(ids))
+	}
+	reshuffled := false
+	for i := 0; i < numRounds; i++ {
+		round := ids[i*len(tracks) : (i+1)*len(tracks)]
+		if i != 0 && !equalAudioIDs(round, ids[:len(tracks)]) {
+			reshuffled = true
+		}
+		// Every round plays every track exactly once.
+		sorted := append([]resource.AudioID(nil), round...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		if !equalAudioIDs(sorted, tracks) {
+			t.Fatalf("round %d is not a permutation: %v", i, round)
+		}
+	}
+	if !reshuffled {
+		t.Fatalf("the order is not randomized on repeat: %v", ids)
+	}
+}
+
+func equalAudioIDs(a, b []resource.AudioID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+package ge
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sync/atomic"
+
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/gmath"
+)
+
+type AttenuationCurve uint8
+
+const (
+	// AttenuationLinear decreases the volume linearly from MinDistance to MaxDistance.
+	AttenuationLinear AttenuationCurve = iota
+
+	// AttenuationInverse decreases the volume proportionally to MinDistance/distance.
+	// It's closer to the real-world sound propagation.
+	AttenuationInverse
+
+	// AttenuationQuadratic keeps the volume high near the listener and
+	// then decreases it quickly when getting closer to the MaxDistance.
+	AttenuationQuadratic
+)
+
+// SoundAttenuation describes how the positional sounds are affected by the distance to the listener.
+type SoundAttenuation struct {
+	Curve AttenuationCurve
+
+	// MinDistance is a radius around the listener where sounds are played at the full volume.
+	MinDistance float64
+
+	// MaxDistance is a distance at which sounds become silent.
+	MaxDistance float64
+
+	// PanWidth is a horizontal distance from the listener at which
+	// the sound is played only from one stereo channel.
+	// Zero disables the panning.
+	PanWidth float64
+}
+
+// DefaultSoundAttenuation returns the attenuation settings used by the AudioSystem by default.
+func DefaultSoundAttenuation() SoundAttenuation {
+	return SoundAttenuation{
+		Curve:       AttenuationLinear,
+		MinDistance: 64,
+		MaxDistance: 800,
+		PanWidth:    480,
+	}
+}
+
+// Gain returns the volume multiplier for the specified distance.
+func (a *SoundAttenuation) Gain(dist float64) float64 {
+	if dist <= a.MinDistance {
+		return 1
+	}
+	if dist >= a.MaxDistance {
+		return 0
+	}
+	t := (dist - a.MinDistance) / (a.MaxDistance - a.MinDistance)
+	switch a.Curve {
+	case AttenuationInverse:
+		return a.MinDistance / dist
+	case AttenuationQuadratic:
+		return 1 - t*t
+	default:
+		return 1 - t
+	}
+}
+
+// Pan returns the stereo panning value in [-1, 1] range for the
+// specified horizontal offset from the listener.
+func (a *SoundAttenuation) Pan(dx float64) float64 {
+	if a.PanWidth == 0 {
+		return 0
+	}
+	return gmath.Clamp(dx/a.PanWidth, -1, 1)
+}
+
+// PlaySoundAt is like PlaySound, but the sound is positioned in the world.
+//
+// The sound volume and stereo panning depend on its position relative to the listener
+// (see AudioSystem.ListenerPos) and the AudioSystem.Attenuation settings.
+// If pos has a base, the sound follows it while playing.
+func (sys *AudioSystem) PlaySoundAt(id resource.AudioID, pos Pos) SoundHandle {
+	return sys.PlaySoundAtWithVolume(id, pos, 1.0)
+}
+
+func (sys *AudioSystem) PlaySoundAtWithVolume(id resource.AudioID, pos Pos, vol float64) SoundHandle {
+	if sys.muted {
+		return SoundHandle{}
+	}
+	return sys.playSound(id, vol, &pos)
+}
+
+// pannedStream applies the stereo balance to a 16-bit stereo PCM stream.
+type pannedStream struct {
+	src io.Reader
+
+	// pan is accessed from the audio goroutine,
+	// so it's stored as atomic float64 bits.
+	pan uint64
+}
+
+func (s *pannedStream) SetPan(pan float64) {
+	atomic.StoreUint64(&s.pan, math.Float64bits(pan))
+}
+
+// Seek makes the stream rewindable if its source supports that.
+func (s *pannedStream) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := s.src.(io.Seeker)
+	if !ok {
+		return 0, errors.New("pannedStream: source is not seekable")
+	}
+	return seeker.Seek(offset, whence)
+}
+
+func (s *pannedStream) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if len(p) < bytesPerSample {
+		// A zero-progress read with a nil error would make the caller loop forever.
+		return 0, io.ErrShortBuffer
+	}
+	// Only the whole frames are processed.
+	p = p[:len(p)&^(bytesPerSample-1)]
+	n, err := io.ReadFull(s.src, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	n &^= bytesPerSample - 1
+
+	pan := math.Float64frombits(atomic.LoadUint64(&s.pan))
+	if pan == 0 {
+		return n, err
+	}
+	left := math.Min(1, 1-pan)
+	right := math.Min(1, 1+pan)
+	for i := 0; i < n; i += bytesPerSample {
+		l := int16(binary.LittleEndian.Uint16(p[i:]))
+		r := int16(binary.LittleEndian.Uint16(p[i+2:]))
+		binary.LittleEndian.PutUint16(p[i:], uint16(int16(float64(l)*left)))
+		binary.LittleEndian.PutUint16(p[i+2:], uint16(int16(float64(r)*right)))
+	}
+	return n, err
+}
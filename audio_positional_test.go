@@ -0,0 +1,82 @@
+package ge
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func TestPannedStream(t *testing.T) {
+	samples := []int16{1000, 1000, -2000, -2000, 400, 400}
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, samples)
+
+	tests := []struct {
+		pan  float64
+		want []int16
+	}{
+		{0, []int16{1000, 1000, -2000, -2000, 400, 400}},
+		{-1, []int16{1000, 0, -2000, 0, 400, 0}},
+		{0.5, []int16{500, 1000, -1000, -2000, 200, 400}},
+	}
+
+	for _, test := range tests {
+		s := &pannedStream{src: bytes.NewReader(buf.Bytes())}
+		s.SetPan(test.pan)
+		data, err := io.ReadAll(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		have := make([]int16, len(data)/2)
+		binary.Read(bytes.NewReader(data), binary.LittleEndian, have)
+		for i := range have {
+			if have[i] != test.want[i] {
+				t.Fatalf("pan=%v:\nhave: %v\nwant: %v", test.pan, have, test.want)
+			}
+		}
+	}
+}
+
+func TestPannedStreamShortBuffer(t *testing.T) {
+	s := &pannedStream{src: bytes.NewReader(make([]byte, 16))}
+	for size := 1; size < bytesPerSample; size++ {
+		n, err := s.Read(make([]byte, size))
+		if n != 0 || err != io.ErrShortBuffer {
+			t.Fatalf("read(%d bytes): have (%d, %v), want (0, %v)", size, n, err, io.ErrShortBuffer)
+		}
+	}
+	n, err := s.Read(make([]byte, bytesPerSample+3))
+	if n != bytesPerSample || err != nil {
+		t.Fatalf("read(%d bytes): have (%d, %v), want (%d, nil)", bytesPerSample+3, n, err, bytesPerSample)
+	}
+}
+
+func TestSoundAttenuation(t *testing.T) {
+	a := SoundAttenuation{MinDistance: 100, MaxDistance: 300, PanWidth: 200}
+	tests := []struct {
+		curve AttenuationCurve
+		dist  float64
+		want  float64
+	}{
+		{AttenuationLinear, 50, 1},
+		{AttenuationLinear, 200, 0.5},
+		{AttenuationLinear, 300, 0},
+		{AttenuationInverse, 200, 0.5},
+		{AttenuationQuadratic, 200, 0.75},
+		{AttenuationQuadratic, 400, 0},
+	}
+	for _, test := range tests {
+		a.Curve = test.curve
+		if have := a.Gain(test.dist); have != test.want {
+			t.Fatalf("curve=%d dist=%v: have %v, want %v", test.curve, test.dist, have, test.want)
+		}
+	}
+
+	if pan := a.Pan(-100); pan != -0.5 {
+		t.Fatalf("pan(-100): have %v, want -0.5", pan)
+	}
+	if pan := a.Pan(1000); pan != 1 {
+		t.Fatalf("pan(1000): have %v, want 1", pan)
+	}
+}
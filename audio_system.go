@@ -3,11 +3,13 @@ package ge
 import (
 	"io"
 	"runtime"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/audio"
 	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
 	"github.com/hajimehoshi/ebiten/v2/audio/wav"
 	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/gmath"
 )
 
 const (
@@ -44,10 +46,33 @@ type AudioSystem struct {
 	loader *resource.Loader
 
 	currentMusic resource.Audio
+	music        musicState
+
+	// ListenerPos is a position the positional sounds are heard from.
+	// It's usually bound to the camera center or to the player-controlled object.
+	ListenerPos Pos
+
+	// Attenuation describes how the positional sounds fade with the distance.
+	Attenuation SoundAttenuation
+
+	// MaxVoices is a max number of sounds that can play at the same time.
+	// See SoundConfig for the details.
+	MaxVoices int
+
+	soundPools     map[resource.AudioID]*soundPool
+	activeVoices   []*soundVoice
+	effectsVersion int
+
+	// rand is used for the sound jitter.
+	// It's separate from the Context.Rand, so the audio doesn't
+	// affect the gameplay randomness sequence.
+	rand gmath.Rand
+
+	time float64
 
 	audioContext *audio.Context
 
-	currentQueueSound resource.Audio
+	currentQueueSound SoundHandle
 	soundQueue        []resource.AudioID
 
 	// This small bitset is used to track sounds with id<maxSoundMapID.
@@ -56,7 +81,14 @@ type AudioSystem struct {
 	// is more efficient.
 	soundMap soundMap
 
-	groupVolume [4]float64
+	groupVolume []float64
+
+	master       *AudioBus
+	buses        map[string]*AudioBus
+	busList      []*AudioBus
+	groupBuses   []*AudioBus
+	duckings     []*audioDucking
+	numSoloBuses int
 
 	muted bool
 }
@@ -67,13 +99,25 @@ type audioResource struct {
 	volume float64
 }
 
-func (sys *AudioSystem) init(audioContext *audio.Context, l *resource.Loader) {
+func (sys *AudioSystem) init(audioContext *audio.Context, l *resource.Loader, muted bool) {
 	sys.loader = l
+	sys.rand.SetSeed(time.Now().UnixNano())
 	sys.audioContext = audioContext
 	sys.soundQueue = make([]resource.AudioID, 0, 4)
+	sys.Attenuation = DefaultSoundAttenuation()
+	sys.MaxVoices = defaultMaxVoices
+
+	sys.buses = make(map[string]*AudioBus)
+	sys.master = newAudioBus(sys, AudioBusMaster, nil)
+	sys.buses[AudioBusMaster] = sys.master
+	sys.busList = append(sys.busList, sys.master)
+	for _, name := range []string{AudioBusMusic, AudioBusSFX, AudioBusVoice, AudioBusUI} {
+		sys.AddBus(name, AudioBusMaster)
+	}
 
-	for i := range sys.groupVolume {
-		sys.groupVolume[i] = 1.0
+	sys.muted = muted
+	if muted {
+		return
 	}
 
 	if runtime.GOOS != "android" {
@@ -86,20 +130,33 @@ func (sys *AudioSystem) init(audioContext *audio.Context, l *resource.Loader) {
 	}
 }
 
+// SetRandSeed re-seeds the random source that is used for the sound jitter.
+// It can be used to make the audio output reproducible.
+func (sys *AudioSystem) SetRandSeed(seed int64) {
+	sys.rand.SetSeed(seed)
+}
+
 func (sys *AudioSystem) GetContext() *audio.Context {
 	return sys.audioContext
 }
 
-func (sys *AudioSystem) Update() {
+// Update advances the mixer state (volume ramps, ducking) and plays the enqueued sounds.
+// It's called automatically by the game runner.
+func (sys *AudioSystem) Update(delta float64) {
 	sys.soundMap.Reset()
+	sys.time += delta
 
-	if sys.currentQueueSound.Player == nil {
+	sys.updateMusic(delta)
+	sys.updateVoices()
+	sys.updateBuses(delta)
+
+	if sys.currentQueueSound == (SoundHandle{}) {
 		if len(sys.soundQueue) == 0 {
 			// Nothing to play in the queue.
 			return
 		}
 		// Do a dequeue.
-		sys.currentQueueSound = sys.playSound(sys.soundQueue[0], 1)
+		sys.currentQueueSound = sys.playSound(sys.soundQueue[0], 1, nil)
 		for i, id := range sys.soundQueue[1:] {
 			sys.soundQueue[i] = id
 		}
@@ -107,83 +164,141 @@ func (sys *AudioSystem) Update() {
 		return
 	}
 
-	if !sys.currentQueueSound.Player.IsPlaying() {
+	if !sys.currentQueueSound.IsPlaying() {
 		// Finished playing the current enqueued sound.
-		sys.currentQueueSound = resource.Audio{}
+		sys.currentQueueSound = SoundHandle{}
+	}
+}
+
+func (sys *AudioSystem) updateBuses(delta float64) {
+	for _, b := range sys.busList {
+		b.update(delta)
+		b.duck = 1
+	}
+	for _, d := range sys.duckings {
+		d.update(delta)
+		d.target.duck *= d.current
+	}
+	for _, b := range sys.busList {
+		b.applyVolume()
 	}
 }
 
+// SetGroupVolume sets a volume multiplier for all sounds of the specified resource group.
+// Unlike the bus volume, it's applied only to the sounds that are played after this call.
 func (sys *AudioSystem) SetGroupVolume(groupID uint, multiplier float64) {
-	if groupID >= uint(len(sys.groupVolume)) {
-		panic("invalid group ID")
+	for uint(len(sys.groupVolume)) <= groupID {
+		sys.groupVolume = append(sys.groupVolume, 1.0)
 	}
 	sys.groupVolume[groupID] = multiplier
 }
 
-func (sys *AudioSystem) DecodeWAV(r io.Reader) (*wav.Stream, error) {
-	return wav.Decode(sys.audioContext, r)
+func (sys *AudioSystem) getGroupVolume(groupID uint) float64 {
+	if groupID < uint(len(sys.groupVolume)) {
+		return sys.groupVolume[groupID]
+	}
+	return 1.0
 }
 
-func (sys *AudioSystem) DecodeOGG(r io.Reader) (*vorbis.Stream, error) {
-	return vorbis.Decode(sys.audioContext, r)
+// AddBus creates a new audio bus that is routed into the parent bus.
+// Bus names should be unique.
+func (sys *AudioSystem) AddBus(name, parent string) *AudioBus {
+	if name == "" {
+		panic("empty audio bus name")
+	}
+	if _, ok := sys.buses[name]; ok {
+		panic("redefined audio bus: " + name)
+	}
+	b := newAudioBus(sys, name, sys.Bus(parent))
+	sys.buses[name] = b
+	sys.busList = append(sys.busList, b)
+	return b
 }
 
-func (sys *AudioSystem) MusicIsPlaying() bool {
-	if sys.currentMusic.Player == nil {
-		return false
+// Bus returns the audio bus with the specified name.
+// It panics if there is no such bus.
+func (sys *AudioSystem) Bus(name string) *AudioBus {
+	b, ok := sys.buses[name]
+	if !ok {
+		panic("undefined audio bus: " + name)
 	}
-	return sys.currentMusic.Player.IsPlaying()
+	return b
 }
 
-func (sys *AudioSystem) PauseCurrentMusic() {
-	if sys.muted {
-		return
+func (sys *AudioSystem) MasterBus() *AudioBus { return sys.master }
+
+// SetGroupBus routes all sounds of the specified resource group through the bus.
+//
+// By default, the music is routed through the AudioBusMusic and
+// all other sounds are routed through the AudioBusSFX.
+func (sys *AudioSystem) SetGroupBus(groupID uint, bus string) {
+	b := sys.Bus(bus)
+	for uint(len(sys.groupBuses)) <= groupID {
+		sys.groupBuses = append(sys.groupBuses, nil)
 	}
-	if sys.currentMusic.Player == nil {
-		return
+	sys.groupBuses[groupID] = b
+}
+
+func (sys *AudioSystem) busFor(res resource.Audio, defaultBus string) *AudioBus {
+	if res.Group < uint(len(sys.groupBuses)) {
+		if b := sys.groupBuses[res.Group]; b != nil {
+			return b
+		}
 	}
-	sys.currentMusic.Player.Pause()
+	return sys.buses[defaultBus]
 }
 
-func (sys *AudioSystem) ContinueCurrentMusic() {
-	if sys.muted {
-		return
+// AddDucking lowers the target bus volume to the specified level
+// while any sound of the trigger bus is playing.
+// A typical example is lowering the music volume during the voice lines.
+//
+// The attack and release are the transition durations (in seconds)
+// for lowering the volume and restoring it back.
+func (sys *AudioSystem) AddDucking(target, trigger string, level, attack, release float64) {
+	sys.duckings = append(sys.duckings, &audioDucking{
+		target:  sys.Bus(target),
+		trigger: sys.Bus(trigger),
+		level:   level,
+		attack:  attack,
+		release: release,
+		current: 1,
+	})
+}
+
+// Settings returns the current mixer settings.
+// The volume of the buses that are being ramped is reported as the target value.
+func (sys *AudioSystem) Settings() AudioSettings {
+	settings := AudioSettings{
+		Buses: make(map[string]AudioBusSettings, len(sys.buses)),
 	}
-	if sys.currentMusic.Player == nil || sys.currentMusic.Player.IsPlaying() {
-		return
+	for name, b := range sys.buses {
+		settings.Buses[name] = AudioBusSettings{
+			Volume: b.targetVolume,
+			Muted:  b.muted,
+		}
 	}
-	sys.currentMusic.Player.SetVolume(sys.currentMusic.Volume * sys.groupVolume[sys.currentMusic.Group])
-	sys.currentMusic.Player.Play()
+	return settings
 }
 
-func (sys *AudioSystem) ContinueMusic(id resource.AudioID) {
-	if sys.muted {
-		return
+// ApplySettings restores the mixer settings.
+// The settings for the undefined buses are ignored.
+func (sys *AudioSystem) ApplySettings(settings AudioSettings) {
+	for name, s := range settings.Buses {
+		b, ok := sys.buses[name]
+		if !ok {
+			continue
+		}
+		b.SetVolume(s.Volume)
+		b.SetMuted(s.Muted)
 	}
-	sys.continueMusic(sys.loader.LoadAudio(id))
 }
 
-func (sys *AudioSystem) continueMusic(res resource.Audio) {
-	if res.Player.IsPlaying() {
-		return
-	}
-	sys.currentMusic = res
-	res.Player.SetVolume(res.Volume * sys.groupVolume[res.Group])
-	res.Player.Play()
+func (sys *AudioSystem) DecodeWAV(r io.Reader) (*wav.Stream, error) {
+	return wav.Decode(sys.audioContext, r)
 }
 
-func (sys *AudioSystem) PlayMusic(id resource.AudioID) {
-	if sys.muted {
-		return
-	}
-	res := sys.loader.LoadAudio(id)
-	if sys.currentMusic.Player != nil && res.Player == sys.currentMusic.Player && res.Player.IsPlaying() {
-		return
-	}
-	sys.currentMusic = res
-	res.Player.SetVolume(res.Volume * sys.groupVolume[res.Group])
-	res.Player.Rewind()
-	res.Player.Play()
+func (sys *AudioSystem) DecodeOGG(r io.Reader) (*vorbis.Stream, error) {
+	return vorbis.Decode(sys.audioContext, r)
 }
 
 func (sys *AudioSystem) ResetQueue() {
@@ -197,28 +312,21 @@ func (sys *AudioSystem) EnqueueSound(id resource.AudioID) {
 	sys.soundQueue = append(sys.soundQueue, id)
 }
 
-func (sys *AudioSystem) PlaySound(id resource.AudioID) {
-	sys.PlaySoundWithVolume(id, 1.0)
+// PlaySound plays the sound and returns its instance handle.
+//
+// Playing the same sound several times during a single frame has the same effect as playing it once.
+// A zero handle is returned if the sound was not played due to its limits (see SoundConfig).
+func (sys *AudioSystem) PlaySound(id resource.AudioID) SoundHandle {
+	return sys.PlaySoundWithVolume(id, 1.0)
 }
 
-func (sys *AudioSystem) PlaySoundWithVolume(id resource.AudioID, vol float64) {
+func (sys *AudioSystem) PlaySoundWithVolume(id resource.AudioID, vol float64) SoundHandle {
 	if sys.muted {
-		return
+		return SoundHandle{}
 	}
 	if sys.soundMap.IsSet(uint(id)) {
-		return
+		return SoundHandle{}
 	}
 	sys.soundMap.Set(uint(id))
-	sys.playSound(id, vol)
-}
-
-func (sys *AudioSystem) playSound(id resource.AudioID, vol float64) resource.Audio {
-	res := sys.loader.LoadWAV(id)
-	volumeMultiplier := sys.groupVolume[res.Group] * vol
-	if volumeMultiplier != 0 {
-		res.Player.SetVolume(res.Volume * volumeMultiplier)
-		res.Player.Rewind()
-		res.Player.Play()
-	}
-	return res
+	return sys.playSound(id, vol, nil)
 }
@@ -0,0 +1,298 @@
+package ge
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	resource "github.com/quasilyte/ebitengine-resource"
+)
+
+// defaultMaxVoices is a default limit of simultaneously playing sounds.
+const defaultMaxVoices = 32
+
+// SoundConfig describes the playback limits of a sound.
+type SoundConfig struct {
+	// MaxVoices is a max number of this sound instances that can play at the same time.
+	// When this limit is reached, the oldest instance is stopped to play a new one.
+	// Zero means "no limit" (but the AudioSystem.MaxVoices limit still applies).
+	MaxVoices int
+
+	// Cooldown is a min interval between this sound plays (in seconds).
+	// The plays that happen during the cooldown are ignored.
+	Cooldown float64
+
+	// PitchJitter is a max random pitch deviation that is applied to every play.
+	// For example, 0.1 means that the pitch is randomized in [0.9, 1.1] range.
+	PitchJitter float64
+
+	// VolumeJitter is a max random volume deviation that is applied to every play.
+	// For example, 0.2 means that the volume is multiplied by a value in [0.8, 1.2] range.
+	VolumeJitter float64
+
+	// Priority is used to select a victim when the AudioSystem.MaxVoices limit is reached.
+	// A sound can only interrupt the sounds with the same or lower priority;
+	// the oldest of the lowest priority sounds is interrupted.
+	Priority int
+}
+
+// SoundHandle identifies a specific playing sound instance.
+//
+// The handle becomes invalid after the instance stops playing;
+// it's safe to call its methods after that, they will have no effect.
+// A zero value handle is always invalid.
+type SoundHandle struct {
+	voice      *soundVoice
+	generation uint64
+}
+
+func (h SoundHandle) isValid() bool {
+	return h.voice != nil && h.voice.generation == h.generation
+}
+
+// IsPlaying reports whether this sound instance is still playing.
+func (h SoundHandle) IsPlaying() bool {
+	return h.isValid() && h.voice.player.IsPlaying()
+}
+
+// Stop stops this sound instance.
+func (h SoundHandle) Stop() {
+	if h.isValid() {
+		h.voice.player.Pause()
+	}
+}
+
+// SetVolume changes the volume multiplier that was passed to the play method.
+func (h SoundHandle) SetVolume(vol float64) {
+	if h.isValid() {
+		h.voice.playVolume = vol
+	}
+}
+
+// SetPitch changes the sound playback speed (and its pitch).
+// 1 is the normal pitch, 2 is one octave higher, 0.5 is one octave lower.
+func (h SoundHandle) SetPitch(pitch float64) {
+	if h.isValid() {
+		h.voice.pitch.SetPitch(pitch)
+	}
+}
+
+// SetPos changes the sound position.
+// It has no effect on the non-positional sounds.
+func (h SoundHandle) SetPos(pos Pos) {
+	if h.isValid() && h.voice.positional {
+		h.voice.pos = pos
+	}
+}
+
+type soundVoice struct {
+	pool *soundPool
+
+	player *audio.Player
+	stream *pannedStream
+	pitch  *pitchStream
+
+	effectsVersion int
+
+	baseVolume float64
+	playVolume float64
+
+	positional bool
+	pos        Pos
+
+	generation uint64
+}
+
+type soundPool struct {
+	id     resource.AudioID
+	info   resource.AudioInfo
+	data   []byte
+	config SoundConfig
+
+	idle      []*soundVoice
+	numActive int
+
+	played     bool
+	lastPlayed float64
+}
+
+// SetSoundConfig assigns the playback limits for the sound.
+func (sys *AudioSystem) SetSoundConfig(id resource.AudioID, config SoundConfig) {
+	sys.getSoundPool(id).config = config
+}
+
+// SetSoundData assigns the decoded PCM data to the sound, so it's not loaded from its file.
+// It can be used to play the procedurally generated sounds (see the ge/sfx package).
+//
+// The data should be in 16-bit stereo format with the audio context sample rate.
+// The sound info (volume, group) is still taken from the loader registry.
+func (sys *AudioSystem) SetSoundData(id resource.AudioID, data []byte) {
+	pool := sys.getSoundPool(id)
+	pool.data = data
+	// The idle players are bound to the old data.
+	for _, v := range pool.idle {
+		v.player.Close()
+	}
+	pool.idle = pool.idle[:0]
+}
+
+func (sys *AudioSystem) getSoundPool(id resource.AudioID) *soundPool {
+	if pool, ok := sys.soundPools[id]; ok {
+		return pool
+	}
+	if sys.soundPools == nil {
+		sys.soundPools = make(map[resource.AudioID]*soundPool)
+	}
+	pool := &soundPool{
+		id:   id,
+		info: sys.loader.GetAudioInfo(id),
+	}
+	sys.soundPools[id] = pool
+	return pool
+}
+
+func (sys *AudioSystem) playSound(id resource.AudioID, vol float64, pos *Pos) SoundHandle {
+	pool := sys.getSoundPool(id)
+
+	if pool.played && pool.config.Cooldown > 0 && sys.time-pool.lastPlayed < pool.config.Cooldown {
+		return SoundHandle{}
+	}
+	baseVolume := ((pool.info.Volume / 2) + 0.5) * sys.getGroupVolume(pool.info.Group)
+	if baseVolume*vol == 0 {
+		return SoundHandle{}
+	}
+
+	if pool.config.MaxVoices > 0 && pool.numActive >= pool.config.MaxVoices {
+		sys.releaseVoice(sys.findVoice(func(v *soundVoice) bool {
+			return v.pool == pool
+		}))
+	} else if sys.MaxVoices > 0 && len(sys.activeVoices) >= sys.MaxVoices {
+		victim := sys.findVictimVoice()
+		if victim == nil || victim.pool.config.Priority > pool.config.Priority {
+			return SoundHandle{}
+		}
+		sys.releaseVoice(victim)
+	}
+
+	pitch := 1.0
+	if j := pool.config.PitchJitter; j != 0 {
+		pitch += sys.rand.FloatRange(-j, j)
+	}
+	if j := pool.config.VolumeJitter; j != 0 {
+		vol *= 1 + sys.rand.FloatRange(-j, j)
+	}
+
+	v := sys.acquireVoice(pool)
+	v.baseVolume = baseVolume
+	v.playVolume = vol
+	v.pitch.SetPitch(pitch)
+	v.positional = pos != nil
+	v.pos = Pos{}
+	if pos != nil {
+		v.pos = *pos
+	}
+	v.stream.SetPan(0)
+	v.player.Rewind()
+	sys.updateVoice(v)
+	v.player.Play()
+
+	pool.played = true
+	pool.lastPlayed = sys.time
+	return SoundHandle{voice: v, generation: v.generation}
+}
+
+func (sys *AudioSystem) acquireVoice(pool *soundPool) *soundVoice {
+	pool.numActive++
+	for len(pool.idle) != 0 {
+		v := pool.idle[len(pool.idle)-1]
+		pool.idle = pool.idle[:len(pool.idle)-1]
+		if v.effectsVersion != sys.effectsVersion {
+			// The effects chain has changed, this player can't be reused.
+			v.player.Close()
+			continue
+		}
+		sys.activeVoices = append(sys.activeVoices, v)
+		return v
+	}
+
+	if pool.data == nil {
+		pool.data = sys.decodeAudio(pool.info.Path)
+	}
+	pitch := newPitchStream(bytes.NewReader(pool.data))
+	bus := sys.busFor(resource.Audio{Group: pool.info.Group}, AudioBusSFX)
+	src := bus.wrapEffects(pitch, sys.audioContext.SampleRate())
+	stream := &pannedStream{src: src}
+	player, err := sys.audioContext.NewPlayer(stream)
+	if err != nil {
+		panic(fmt.Sprintf("create %q player: %v", pool.info.Path, err))
+	}
+	v := &soundVoice{
+		pool:           pool,
+		player:         player,
+		stream:         stream,
+		pitch:          pitch,
+		effectsVersion: sys.effectsVersion,
+	}
+	sys.activeVoices = append(sys.activeVoices, v)
+	return v
+}
+
+// releaseVoice stops the voice and returns it to its pool.
+func (sys *AudioSystem) releaseVoice(v *soundVoice) {
+	for i, active := range sys.activeVoices {
+		if active == v {
+			copy(sys.activeVoices[i:], sys.activeVoices[i+1:])
+			sys.activeVoices = sys.activeVoices[:len(sys.activeVoices)-1]
+			break
+		}
+	}
+	v.player.Pause()
+	v.generation++
+	v.pool.numActive--
+	v.pool.idle = append(v.pool.idle, v)
+}
+
+// findVoice returns the oldest active voice that satisfies the predicate.
+func (sys *AudioSystem) findVoice(pred func(v *soundVoice) bool) *soundVoice {
+	for _, v := range sys.activeVoices {
+		if pred(v) {
+			return v
+		}
+	}
+	return nil
+}
+
+// findVictimVoice returns the oldest active voice with the lowest priority.
+func (sys *AudioSystem) findVictimVoice() *soundVoice {
+	var victim *soundVoice
+	for _, v := range sys.activeVoices {
+		if victim == nil || v.pool.config.Priority < victim.pool.config.Priority {
+			victim = v
+		}
+	}
+	return victim
+}
+
+func (sys *AudioSystem) updateVoices() {
+	i := 0
+	for i < len(sys.activeVoices) {
+		v := sys.activeVoices[i]
+		if !v.player.IsPlaying() {
+			sys.releaseVoice(v)
+			continue
+		}
+		sys.updateVoice(v)
+		i++
+	}
+}
+
+func (sys *AudioSystem) updateVoice(v *soundVoice) {
+	volume := v.baseVolume * v.playVolume
+	if v.positional {
+		delta := v.pos.Resolve().Sub(sys.ListenerPos.Resolve())
+		volume *= sys.Attenuation.Gain(delta.Len())
+		v.stream.SetPan(sys.Attenuation.Pan(delta.X))
+	}
+	bus := sys.busFor(resource.Audio{Group: v.pool.info.Group}, AudioBusSFX)
+	bus.addVoice(v.player, volume)
+}
@@ -104,11 +104,7 @@ func NewContext(config ContextConfig) *Context {
 	}
 	audioContext := audio.NewContext(44100)
 	ctx.Loader = resource.NewLoader(audioContext)
-	if config.Mute {
-		ctx.Audio.muted = true
-	} else {
-		ctx.Audio.init(audioContext, ctx.Loader)
-	}
+	ctx.Audio.init(audioContext, ctx.Loader, config.Mute)
 	ctx.Renderer = NewRenderer()
 	ctx.Rand.SetSeed(0)
 	// TODO: some platforms don't need touches
@@ -135,7 +131,7 @@ func (ctx *Context) newRootScene(controller SceneController) *RootScene {
 }
 
 func (ctx *Context) Draw(screen *ebiten.Image) {
-	ctx.Renderer.Draw(screen, &ctx.CurrentScene.graphics)
+	ctx.Renderer.Draw(screen, ctx.CurrentScene.layers.list, &ctx.CurrentScene.camera)
 }
 
 func (ctx *Context) WindowRect() gmath.Rect {
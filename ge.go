@@ -64,7 +64,6 @@ func (g *gameRunner) Update() error {
 
 func (g *gameRunner) update() {
 	g.ctx.Input.Update()
-	g.ctx.Audio.Update()
 
 	if g.ctx.CurrentScene == nil && g.ctx.firstController != nil {
 		g.ctx.ChangeScene(g.ctx.firstController)
@@ -80,10 +79,12 @@ func (g *gameRunner) update() {
 		g.prevTime = now
 	}
 
+	g.ctx.Audio.Update(delta)
+
 	if g.ctx.nextScene != nil {
 		g.ctx.CurrentScene = g.ctx.nextScene
 		g.ctx.nextScene = nil
-		scene0 := &g.ctx.CurrentScene.subSceneArray[1]
+		scene0 := g.ctx.CurrentScene.sceneAt(0)
 		g.ctx.CurrentScene.controller.Init(scene0)
 	}
 
@@ -7,5 +7,23 @@ func TestIfaceImpl(t *testing.T) {
 		(*SimpleLayer)(nil),
 		(*YSortLayer)(nil),
 		(*MultiLayer)(nil),
+		(*ShaderLayer)(nil),
+		(*RenderTarget)(nil),
+		(*LightingLayer)(nil),
+	}
+
+	_ = []offsetDrawer{
+		(*Sprite)(nil),
+		(*Rect)(nil),
+		(*Label)(nil),
+		(*Line)(nil),
+		(*TextureLine)(nil),
+		(*NineSlice)(nil),
+		(*SimpleLayer)(nil),
+		(*YSortLayer)(nil),
+		(*MultiLayer)(nil),
+		(*ShaderLayer)(nil),
+		(*RenderTarget)(nil),
+		(*LightingLayer)(nil),
 	}
 }
@@ -0,0 +1,295 @@
+package input
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ResponseCurve maps the normalized analog input magnitude to the action value.
+type ResponseCurve uint8
+
+const (
+	// CurveLinear keeps the input value as is.
+	CurveLinear ResponseCurve = iota
+
+	// CurveQuadratic makes small inputs more precise (value^2).
+	CurveQuadratic
+
+	// CurveCubic makes small inputs even more precise (value^3).
+	CurveCubic
+)
+
+func (c ResponseCurve) apply(v float64) float64 {
+	switch c {
+	case CurveQuadratic:
+		return v * v
+	case CurveCubic:
+		return v * v * v
+	default:
+		return v
+	}
+}
+
+// VectorComposite combines four directional actions into a single vector action.
+//
+// A typical example is WASD movement: the Up action is bound to W,
+// the Left action is bound to A and so on.
+// The resulting vector is normalized, so the diagonal movement is not faster.
+type VectorComposite struct {
+	Up    Action
+	Right Action
+	Down  Action
+	Left  Action
+}
+
+// ActionConfig describes how the action analog values are computed.
+//
+// See Handler.ActionValue and Handler.ActionVector.
+type ActionConfig struct {
+	// Deadzone is a min input magnitude that is registered.
+	// The magnitudes above the deadzone are rescaled to the [0, 1] range,
+	// so there is no value jump at the deadzone border.
+	//
+	// Zero value means Handler.GamepadDeadzone for the gamepad sticks
+	// and no deadzone for the other inputs.
+	Deadzone float64
+
+	// Curve is applied to the magnitude after the deadzone is handled.
+	Curve ResponseCurve
+
+	// Sensitivity is a final magnitude multiplier.
+	// Zero value is interpreted as 1.
+	Sensitivity float64
+
+	// Composite makes the action vector to be computed from the directional actions.
+	// The keys bound to the action itself are still used:
+	// the greatest of the composite and keys vectors is selected.
+	Composite *VectorComposite
+}
+
+// SetActionConfig assigns the analog value settings for the action.
+func (h *Handler) SetActionConfig(action Action, config ActionConfig) {
+	if h.actionConfigs == nil {
+		h.actionConfigs = make(map[Action]ActionConfig)
+	}
+	h.actionConfigs[action] = config
+}
+
+// ActionValue returns the action activation strength.
+//
+// The value is in [0, 1] range before the sensitivity is applied.
+// Digital inputs, like keyboard keys and mouse buttons, report 1 when pressed.
+// Gamepad sticks report the stick deflection, triggers report how deep they are pressed.
+// Mouse wheel keys report the wheel scroll delta.
+//
+// For the actions with a VectorComposite, the vector length is returned.
+func (h *Handler) ActionValue(action Action) float64 {
+	config := h.actionConfigs[action]
+	if config.Composite != nil {
+		return vecLen(h.ActionVector(action))
+	}
+
+	keys := h.keymap[action]
+	value := 0.0
+	analog := false
+	for _, k := range keys {
+		if h.keyIsConsumed(k) {
+			continue
+		}
+		v, isAnalog := h.keyValue(k)
+		if v > value {
+			value = v
+			analog = isAnalog
+		}
+	}
+	if value == 0 && h.sys.hasSimulatedActions && h.ActionIsPressed(action) {
+		value = 1
+	}
+	return h.shapeValue(config, value, analog)
+}
+
+// ActionVector returns the action 2D value.
+//
+// Gamepad sticks report their position, gamepad d-pad buttons report
+// their direction and mouse wheel keys report the wheel scroll delta
+// projected onto their direction.
+// The vector of the most active key is returned.
+//
+// Use ActionConfig.Composite to combine several digital inputs into a vector.
+func (h *Handler) ActionVector(action Action) Vec {
+	config := h.actionConfigs[action]
+
+	var result Vec
+	resultLen := 0.0
+	analog := false
+	for _, k := range h.keymap[action] {
+		if h.keyIsConsumed(k) {
+			continue
+		}
+		v, isAnalog := h.keyVector(k)
+		if l := vecLen(v); l > resultLen {
+			result = v
+			resultLen = l
+			analog = isAnalog
+		}
+	}
+
+	if c := config.Composite; c != nil {
+		v := Vec{
+			X: h.ActionValue(c.Right) - h.ActionValue(c.Left),
+			Y: h.ActionValue(c.Down) - h.ActionValue(c.Up),
+		}
+		if l := vecLen(v); l > resultLen {
+			if l > 1 {
+				v = vecMulf(v, 1/l)
+				l = 1
+			}
+			result = v
+			resultLen = l
+			analog = false
+		}
+	}
+
+	if resultLen == 0 {
+		return Vec{}
+	}
+	shaped := h.shapeValue(config, resultLen, analog)
+	return vecMulf(result, shaped/resultLen)
+}
+
+func (h *Handler) shapeValue(config ActionConfig, v float64, analog bool) float64 {
+	deadzone := config.Deadzone
+	if deadzone == 0 && analog {
+		deadzone = h.GamepadDeadzone
+	}
+	return shapeActionValue(config, v, deadzone)
+}
+
+func shapeActionValue(config ActionConfig, v, deadzone float64) float64 {
+	if v <= deadzone {
+		return 0
+	}
+	if deadzone > 0 && deadzone < 1 {
+		v = (v - deadzone) / (1 - deadzone)
+	}
+	if v < 1 {
+		// The curves are defined for [0, 1]; wheel deltas can exceed that range.
+		v = config.Curve.apply(v)
+	}
+	if config.Sensitivity != 0 {
+		v *= config.Sensitivity
+	}
+	return v
+}
+
+// keyValue returns the key activation strength.
+// The second result reports whether this value comes from the analog input.
+func (h *Handler) keyValue(k Key) (float64, bool) {
+	switch k.kind {
+	case keyGamepadStickMotion:
+		return vecLen(h.getStickVec(h.getStickAxes(stickCode(k.code)))), true
+	case keyGamepadLeftStick:
+		return h.stickDirectionValue(stickCode(k.code), ebiten.StandardGamepadAxisLeftStickHorizontal, ebiten.StandardGamepadAxisLeftStickVertical), true
+	case keyGamepadRightStick:
+		return h.stickDirectionValue(stickCode(k.code), ebiten.StandardGamepadAxisRightStickHorizontal, ebiten.StandardGamepadAxisRightStickVertical), true
+	case keyGamepad:
+		if h.virtualButtonIsPressed(k.code) {
+			return 1, false
+		}
+		if isTriggerButton(k.code) && h.gamepadInfo().model == gamepadStandard {
+			return ebiten.StandardGamepadButtonValue(h.gamepadID(), ebiten.StandardGamepadButton(k.code)), true
+		}
+	case keyWheel:
+		switch wheelCode(k.code) {
+		case wheelUp:
+			return math.Max(0, -h.sys.wheel.Y), false
+		case wheelDown:
+			return math.Max(0, h.sys.wheel.Y), false
+		case wheelVertical:
+			return math.Abs(h.sys.wheel.Y), false
+		}
+		return 0, false
+	}
+
+	if len(h.sys.simulatedEvents) != 0 && h.simulatedKeyIsPressed(k) {
+		return 1, false
+	}
+	if h.keyIsPressed(k) {
+		return 1, false
+	}
+	return 0, false
+}
+
+// keyVector returns the key 2D value.
+// The second result reports whether this value comes from the analog input.
+func (h *Handler) keyVector(k Key) (Vec, bool) {
+	switch k.kind {
+	case keyGamepadStickMotion:
+		return h.getStickVec(h.getStickAxes(stickCode(k.code))), true
+	case keyGamepadLeftStick, keyGamepadRightStick:
+		v, analog := h.keyValue(k)
+		return vecMulf(stickDirection(stickCode(k.code)), v), analog
+	case keyGamepad:
+		if !isDPadButton(k.code) {
+			return Vec{}, false
+		}
+		v, analog := h.keyValue(k)
+		return vecMulf(dpadButtonDirection(k.code), v), analog
+	case keyWheel:
+		// Only the key's own direction component is reported.
+		switch wheelCode(k.code) {
+		case wheelUp:
+			return Vec{Y: -math.Max(0, -h.sys.wheel.Y)}, false
+		case wheelDown:
+			return Vec{Y: math.Max(0, h.sys.wheel.Y)}, false
+		case wheelVertical:
+			return Vec{Y: h.sys.wheel.Y}, false
+		}
+	}
+	return Vec{}, false
+}
+
+func (h *Handler) stickDirectionValue(code stickCode, axis1, axis2 ebiten.StandardGamepadAxis) float64 {
+	vec := h.getStickVec(int(axis1), int(axis2))
+	return math.Max(0, vecDot(vec, stickDirection(code)))
+}
+
+func stickDirection(code stickCode) Vec {
+	switch code {
+	case stickUp:
+		return Vec{Y: -1}
+	case stickRight:
+		return Vec{X: 1}
+	case stickDown:
+		return Vec{Y: 1}
+	case stickLeft:
+		return Vec{X: -1}
+	default:
+		return Vec{}
+	}
+}
+
+func dpadButtonDirection(code int) Vec {
+	switch ebiten.StandardGamepadButton(code) {
+	case ebiten.StandardGamepadButtonLeftTop:
+		return Vec{Y: -1}
+	case ebiten.StandardGamepadButtonLeftRight:
+		return Vec{X: 1}
+	case ebiten.StandardGamepadButtonLeftBottom:
+		return Vec{Y: 1}
+	case ebiten.StandardGamepadButtonLeftLeft:
+		return Vec{X: -1}
+	default:
+		return Vec{}
+	}
+}
+
+func isTriggerButton(code int) bool {
+	switch ebiten.StandardGamepadButton(code) {
+	case ebiten.StandardGamepadButtonFrontBottomLeft, ebiten.StandardGamepadButtonFrontBottomRight:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,62 @@
+package input
+
+import (
+	"math"
+	"testing"
+)
+
+func TestShapeActionValue(t *testing.T) {
+	tests := []struct {
+		config   ActionConfig
+		deadzone float64
+		v        float64
+		want     float64
+	}{
+		{ActionConfig{}, 0, 0.5, 0.5},
+		{ActionConfig{}, 0.2, 0.1, 0},
+		{ActionConfig{}, 0.2, 0.2, 0},
+		{ActionConfig{}, 0.2, 0.6, 0.5},
+		{ActionConfig{}, 0.2, 1, 1},
+		{ActionConfig{Curve: CurveQuadratic}, 0, 0.5, 0.25},
+		{ActionConfig{Curve: CurveCubic}, 0, 0.5, 0.125},
+		{ActionConfig{Curve: CurveQuadratic}, 0.2, 0.6, 0.25},
+		{ActionConfig{Sensitivity: 2}, 0, 0.5, 1},
+		{ActionConfig{Curve: CurveQuadratic, Sensitivity: 0.5}, 0, 1, 0.5},
+		{ActionConfig{Curve: CurveQuadratic}, 0, 3, 3},
+	}
+	for _, test := range tests {
+		have := shapeActionValue(test.config, test.v, test.deadzone)
+		if math.Abs(have-test.want) > 1e-9 {
+			t.Fatalf("shape(%+v, %v, deadzone=%v):\nhave: %v\nwant: %v",
+				test.config, test.v, test.deadzone, have, test.want)
+		}
+	}
+}
+
+func TestWheelKeyVector(t *testing.T) {
+	tests := []struct {
+		wheel Vec
+		key   Key
+		want  Vec
+	}{
+		{Vec{Y: -2}, KeyWheelUp, Vec{Y: -2}},
+		{Vec{Y: -2}, KeyWheelDown, Vec{}},
+		{Vec{Y: -2}, KeyWheelVertical, Vec{Y: -2}},
+		{Vec{Y: 3}, KeyWheelUp, Vec{}},
+		{Vec{Y: 3}, KeyWheelDown, Vec{Y: 3}},
+		{Vec{Y: 3}, KeyWheelVertical, Vec{Y: 3}},
+		{Vec{X: 1, Y: 1}, KeyWheelDown, Vec{Y: 1}},
+		{Vec{X: 1}, KeyWheelVertical, Vec{}},
+	}
+
+	var sys System
+	h := sys.NewHandler(0, Keymap{})
+	for _, test := range tests {
+		sys.wheel = test.wheel
+		have, analog := h.keyVector(test.key)
+		if have != test.want || analog {
+			t.Fatalf("%s vector with %v wheel:\nhave: %v (analog=%v)\nwant: %v",
+				test.key, test.wheel, have, analog, test.want)
+		}
+	}
+}
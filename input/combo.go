@@ -0,0 +1,207 @@
+package input
+
+// ComboRecognizer detects the timed input patterns like action sequences,
+// double taps, holds and charged releases.
+//
+// Recognized patterns are emitted as simulated actions for the handler's player
+// (see Handler.EmitEvent), so they're handled like any other action:
+//
+//	if h.ActionIsJustPressed(ActionDash) { ... }
+//
+// Call the Update method once per frame after the System.Update.
+// Since the simulated events are delivered with the next System.Update,
+// the result actions are observed with one frame delay.
+//
+// Experimental: this is a part of virtual input API, which is not stable yet.
+type ComboRecognizer struct {
+	input comboInput
+
+	time float64
+
+	sequences []*comboSequence
+	holds     []*comboHold
+}
+
+// comboInput is a subset of the Handler API used by the recognizer.
+type comboInput interface {
+	ActionIsPressed(action Action) bool
+	ActionIsJustPressed(action Action) bool
+	EmitEvent(e SimulatedAction)
+}
+
+type comboSequence struct {
+	result     Action
+	steps      [][]Action
+	stepWindow float64
+
+	index        int
+	lastStepTime float64
+}
+
+type comboHold struct {
+	result   Action
+	action   Action
+	duration float64
+
+	// charged is true for the charged release combos.
+	charged bool
+
+	pressed bool
+	emitted bool
+	charge  float64
+}
+
+func NewComboRecognizer(h *Handler) *ComboRecognizer {
+	return &ComboRecognizer{input: h}
+}
+
+// AddSequence registers an action sequence.
+//
+// Every step is a set of actions that should be pressed at the same time,
+// with at least one of them being just pressed.
+// For example, a classic fireball motion can be described like this:
+//
+//	r.AddSequence(ActionFireball, 0.25,
+//		[]input.Action{ActionDown},
+//		[]input.Action{ActionDown, ActionForward},
+//		[]input.Action{ActionForward, ActionPunch})
+//
+// The stepWindow is a max delay between the steps (in seconds).
+func (r *ComboRecognizer) AddSequence(result Action, stepWindow float64, steps ...[]Action) {
+	if len(steps) == 0 {
+		panic("empty combo sequence")
+	}
+	r.sequences = append(r.sequences, &comboSequence{
+		result:     result,
+		steps:      steps,
+		stepWindow: stepWindow,
+	})
+}
+
+// AddDoubleTap registers a double tap pattern: the action is
+// pressed twice with at most interval seconds between the presses.
+func (r *ComboRecognizer) AddDoubleTap(result, action Action, interval float64) {
+	r.AddSequence(result, interval, []Action{action}, []Action{action})
+}
+
+// AddHold registers a hold pattern: the result action is emitted
+// once the action is being held for the specified duration (in seconds).
+func (r *ComboRecognizer) AddHold(result, action Action, duration float64) {
+	r.holds = append(r.holds, &comboHold{
+		result:   result,
+		action:   action,
+		duration: duration,
+	})
+}
+
+// AddChargedRelease registers a charged release pattern: the result action
+// is emitted when the action is released after being held for at least minCharge seconds.
+//
+// Use ChargeTime to get the charge level.
+func (r *ComboRecognizer) AddChargedRelease(result, action Action, minCharge float64) {
+	r.holds = append(r.holds, &comboHold{
+		result:   result,
+		action:   action,
+		duration: minCharge,
+		charged:  true,
+	})
+}
+
+// ChargeTime returns the charge duration (in seconds) of the charged release pattern.
+//
+// While the action is being held, it reports the current charge.
+// After the release, it reports the released charge until the next press.
+func (r *ComboRecognizer) ChargeTime(result Action) float64 {
+	for _, h := range r.holds {
+		if h.charged && h.result == result {
+			return h.charge
+		}
+	}
+	return 0
+}
+
+// Reset drops all in-progress patterns.
+func (r *ComboRecognizer) Reset() {
+	for _, s := range r.sequences {
+		s.index = 0
+	}
+	for _, h := range r.holds {
+		h.pressed = false
+		h.emitted = false
+		h.charge = 0
+	}
+}
+
+func (r *ComboRecognizer) Update(delta float64) {
+	r.time += delta
+
+	for _, s := range r.sequences {
+		r.updateSequence(s)
+	}
+	for _, h := range r.holds {
+		r.updateHold(h, delta)
+	}
+}
+
+func (r *ComboRecognizer) updateSequence(s *comboSequence) {
+	if s.index != 0 && r.time-s.lastStepTime > s.stepWindow {
+		s.index = 0
+	}
+
+	switch {
+	case r.stepIsActive(s.steps[s.index]):
+		s.index++
+	case s.index != 0 && r.stepIsActive(s.steps[0]):
+		// The sequence is interrupted, but a new attempt can be started right away.
+		s.index = 1
+	default:
+		return
+	}
+	s.lastStepTime = r.time
+
+	if s.index == len(s.steps) {
+		s.index = 0
+		r.input.EmitEvent(SimulatedAction{Action: s.result})
+	}
+}
+
+func (r *ComboRecognizer) stepIsActive(step []Action) bool {
+	justPressed := false
+	for _, a := range step {
+		if !r.input.ActionIsPressed(a) {
+			return false
+		}
+		if r.input.ActionIsJustPressed(a) {
+			justPressed = true
+		}
+	}
+	return justPressed
+}
+
+func (r *ComboRecognizer) updateHold(h *comboHold, delta float64) {
+	pressed := r.input.ActionIsPressed(h.action)
+
+	switch {
+	case pressed && !h.pressed:
+		// A new press.
+		h.pressed = true
+		h.emitted = false
+		h.charge = 0
+	case pressed:
+		h.charge += delta
+	case h.pressed:
+		// The action is released.
+		h.pressed = false
+		if h.charged && h.charge >= h.duration {
+			r.input.EmitEvent(SimulatedAction{Action: h.result})
+		}
+		return
+	default:
+		return
+	}
+
+	if !h.charged && !h.emitted && h.charge >= h.duration {
+		h.emitted = true
+		r.input.EmitEvent(SimulatedAction{Action: h.result})
+	}
+}
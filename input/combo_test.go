@@ -0,0 +1,145 @@
+package input
+
+import (
+	"testing"
+)
+
+type testComboInput struct {
+	pressed     map[Action]bool
+	justPressed map[Action]bool
+	emitted     []Action
+}
+
+func (in *testComboInput) ActionIsPressed(action Action) bool { return in.pressed[action] }
+
+func (in *testComboInput) ActionIsJustPressed(action Action) bool { return in.justPressed[action] }
+
+func (in *testComboInput) EmitEvent(e SimulatedAction) { in.emitted = append(in.emitted, e.Action) }
+
+// press sets the new pressed actions set.
+func (in *testComboInput) press(actions ...Action) {
+	prev := in.pressed
+	in.pressed = make(map[Action]bool)
+	in.justPressed = make(map[Action]bool)
+	for _, a := range actions {
+		in.pressed[a] = true
+		if !prev[a] {
+			in.justPressed[a] = true
+		}
+	}
+}
+
+func TestComboRecognizer(t *testing.T) {
+	const (
+		actionDown Action = iota
+		actionForward
+		actionPunch
+		actionFireball
+		actionDash
+		actionGuard
+		actionCharge
+	)
+
+	type frame struct {
+		delta   float64
+		pressed []Action
+		emitted []Action
+	}
+
+	tests := []struct {
+		name   string
+		frames []frame
+	}{
+		{
+			name: "fireball",
+			frames: []frame{
+				{0.1, []Action{actionDown}, nil},
+				{0.1, []Action{actionDown, actionForward}, nil},
+				{0.1, []Action{actionForward, actionPunch}, []Action{actionFireball}},
+			},
+		},
+		{
+			name: "fireball too slow",
+			frames: []frame{
+				{0.1, []Action{actionDown}, nil},
+				{0.1, []Action{actionDown, actionForward}, nil},
+				{0.1, []Action{actionForward}, nil},
+				{0.3, []Action{actionForward, actionPunch}, nil},
+			},
+		},
+		{
+			name: "fireball restarted",
+			frames: []frame{
+				{0.1, []Action{actionDown}, nil},
+				{0.1, nil, nil},
+				{0.1, []Action{actionDown}, nil},
+				{0.1, []Action{actionDown, actionForward}, nil},
+				{0.1, []Action{actionForward, actionPunch}, []Action{actionFireball}},
+			},
+		},
+		{
+			name: "double tap",
+			frames: []frame{
+				{0.1, []Action{actionForward}, nil},
+				{0.1, nil, nil},
+				{0.1, []Action{actionForward}, []Action{actionDash}},
+				{0.1, nil, nil},
+				{0.1, []Action{actionForward}, nil},
+			},
+		},
+		{
+			name: "hold",
+			frames: []frame{
+				{0.1, []Action{actionPunch}, nil},
+				{0.5, []Action{actionPunch}, nil},
+				{0.5, []Action{actionPunch}, []Action{actionGuard}},
+				{0.5, []Action{actionPunch}, nil},
+				{0.1, nil, nil},
+			},
+		},
+		{
+			name: "charged release",
+			frames: []frame{
+				{0.1, []Action{actionDown}, nil},
+				{1.0, []Action{actionDown}, nil},
+				{1.0, []Action{actionDown}, nil},
+				{0.1, nil, []Action{actionCharge}},
+			},
+		},
+		{
+			name: "charged release too early",
+			frames: []frame{
+				{0.1, []Action{actionDown}, nil},
+				{1.0, []Action{actionDown}, nil},
+				{0.1, nil, nil},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := &testComboInput{}
+			r := &ComboRecognizer{input: in}
+			r.AddSequence(actionFireball, 0.25,
+				[]Action{actionDown},
+				[]Action{actionDown, actionForward},
+				[]Action{actionForward, actionPunch})
+			r.AddDoubleTap(actionDash, actionForward, 0.25)
+			r.AddHold(actionGuard, actionPunch, 1)
+			r.AddChargedRelease(actionCharge, actionDown, 1.5)
+			for i, f := range test.frames {
+				in.emitted = in.emitted[:0]
+				in.press(f.pressed...)
+				r.Update(f.delta)
+				if len(in.emitted) != len(f.emitted) {
+					t.Fatalf("frame[%d]: emitted %v, expected %v", i, in.emitted, f.emitted)
+				}
+				for j := range f.emitted {
+					if in.emitted[j] != f.emitted[j] {
+						t.Fatalf("frame[%d]: emitted %v, expected %v", i, in.emitted, f.emitted)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package input
+
+// ContextConfig describes an input context.
+//
+// Input contexts form a stack: the contexts pushed later are above
+// the earlier ones, and all contexts are above the handlers created by NewHandler.
+// A context can consume the input, so the lower layers don't see it.
+//
+// A typical use case is a popup that is opened over the gameplay:
+// the popup UI uses the context handler while the gameplay handler
+// doesn't react to the keys consumed by the popup.
+//
+// Only the handlers with the same player ID are affected by the consumption.
+type ContextConfig struct {
+	// Keymap is a context handler keymap.
+	Keymap Keymap
+
+	// Consume makes the keys bound in this context keymap
+	// invisible for the lower layers.
+	Consume bool
+
+	// Modal contexts consume all input for the lower layers,
+	// including the simulated events.
+	Modal bool
+}
+
+// PushContext creates a new handler and puts it on top of the input contexts stack.
+//
+// Use the returned handler to handle the context input.
+// When the context is not needed anymore (a popup is closed or a scene is changed),
+// use PopContext or RemoveContext to remove it from the stack.
+func (sys *System) PushContext(playerID uint8, config ContextConfig) *Handler {
+	h := sys.NewHandler(playerID, config.Keymap)
+	h.contextConsume = config.Consume
+	h.contextModal = config.Modal
+	sys.contexts = append(sys.contexts, h)
+	return h
+}
+
+// PopContext removes the top input context from the stack.
+// It returns the removed context handler or nil if the stack is empty.
+func (sys *System) PopContext() *Handler {
+	if len(sys.contexts) == 0 {
+		return nil
+	}
+	h := sys.contexts[len(sys.contexts)-1]
+	sys.contexts[len(sys.contexts)-1] = nil
+	sys.contexts = sys.contexts[:len(sys.contexts)-1]
+	return h
+}
+
+// RemoveContext removes the context from the stack.
+// Unlike PopContext, it can remove a context from the middle of the stack.
+//
+// It's a no-op if h is not a context handler.
+func (sys *System) RemoveContext(h *Handler) {
+	for i, c := range sys.contexts {
+		if c == h {
+			copy(sys.contexts[i:], sys.contexts[i+1:])
+			sys.contexts[len(sys.contexts)-1] = nil
+			sys.contexts = sys.contexts[:len(sys.contexts)-1]
+			return
+		}
+	}
+}
+
+// PushContext is like System.PushContext, but it uses the handler's player ID.
+//
+// It's useful for the code that has a handler, but not the System,
+// like the UI widgets that need to capture the input for a while.
+func (h *Handler) PushContext(config ContextConfig) *Handler {
+	return h.sys.PushContext(h.id, config)
+}
+
+// RemoveContext is like System.RemoveContext.
+// The removed context doesn't have to be pushed via this handler.
+func (h *Handler) RemoveContext(c *Handler) {
+	h.sys.RemoveContext(c)
+}
+
+// NumContexts reports the input contexts stack size.
+func (sys *System) NumContexts() int {
+	return len(sys.contexts)
+}
+
+// IsBlocked reports whether this handler input is consumed
+// by a modal context above it.
+func (h *Handler) IsBlocked() bool {
+	return h.keyIsConsumed(Key{kind: keySimulated})
+}
+
+// keyIsConsumed reports whether the key is consumed by one of
+// the contexts above this handler or by the text input mode.
+func (h *Handler) keyIsConsumed(k Key) bool {
+	if h.sys.text.active && k.Device() == KeyboardDevice {
+		return true
+	}
+	if len(h.sys.contexts) == 0 {
+		return false
+	}
+	for i := len(h.sys.contexts) - 1; i >= 0; i-- {
+		c := h.sys.contexts[i]
+		if c == h {
+			// All other contexts are below this handler.
+			return false
+		}
+		if c.id != h.id {
+			continue
+		}
+		if c.contextModal {
+			return true
+		}
+		if c.contextConsume && k.kind != keySimulated && keymapHasKey(c.keymap, k) {
+			return true
+		}
+	}
+	return false
+}
+
+func keymapHasKey(keymap Keymap, k Key) bool {
+	for _, keys := range keymap {
+		for _, k2 := range keys {
+			if k2.code == k.code && k2.kind == k.kind {
+				return true
+			}
+		}
+	}
+	return false
+}
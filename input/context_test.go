@@ -0,0 +1,87 @@
+package input
+
+import (
+	"testing"
+)
+
+func TestContextConsume(t *testing.T) {
+	const (
+		actionFire Action = iota
+		actionJump
+		actionConfirm
+	)
+
+	var sys System
+	game := sys.NewHandler(0, Keymap{
+		actionFire: {KeyEnter, KeyMouseLeft},
+		actionJump: {KeySpace},
+	})
+	otherPlayer := sys.NewHandler(1, Keymap{
+		actionFire: {KeyEnter},
+	})
+
+	if game.keyIsConsumed(KeyEnter) || game.IsBlocked() {
+		t.Fatal("empty contexts stack should not consume anything")
+	}
+
+	popup := sys.PushContext(0, ContextConfig{
+		Keymap:  Keymap{actionConfirm: {KeyEnter}},
+		Consume: true,
+	})
+	if !game.keyIsConsumed(KeyEnter) {
+		t.Fatal("enter should be consumed by the popup")
+	}
+	if game.keyIsConsumed(KeyMouseLeft) || game.keyIsConsumed(KeySpace) {
+		t.Fatal("only the popup keys should be consumed")
+	}
+	if game.IsBlocked() {
+		t.Fatal("non-modal context should not block the handler")
+	}
+	if popup.keyIsConsumed(KeyEnter) {
+		t.Fatal("context should not consume its own keys")
+	}
+	if otherPlayer.keyIsConsumed(KeyEnter) {
+		t.Fatal("context should not consume other player keys")
+	}
+
+	modal := sys.PushContext(0, ContextConfig{Modal: true})
+	if !game.IsBlocked() || !popup.IsBlocked() || !game.keyIsConsumed(KeySpace) {
+		t.Fatal("modal context should block the lower layers")
+	}
+	if modal.IsBlocked() {
+		t.Fatal("top context should not be blocked")
+	}
+
+	sys.RemoveContext(popup)
+	if sys.NumContexts() != 1 {
+		t.Fatalf("unexpected contexts count: %d", sys.NumContexts())
+	}
+	if sys.PopContext() != modal {
+		t.Fatal("pop returned unexpected context")
+	}
+	if game.keyIsConsumed(KeyEnter) || game.IsBlocked() {
+		t.Fatal("removed contexts should not consume anything")
+	}
+	if sys.PopContext() != nil {
+		t.Fatal("pop on empty stack should return nil")
+	}
+}
+
+func TestHandlerPushContext(t *testing.T) {
+	var sys System
+	game := sys.NewHandler(1, Keymap{0: {KeyEscape}})
+	otherPlayer := sys.NewHandler(0, Keymap{0: {KeyEscape}})
+
+	modal := game.PushContext(ContextConfig{Modal: true})
+	if !game.IsBlocked() || !game.keyIsConsumed(KeyEscape) {
+		t.Fatal("modal context should block the handler that pushed it")
+	}
+	if otherPlayer.IsBlocked() {
+		t.Fatal("context should use the handler player ID")
+	}
+
+	game.RemoveContext(modal)
+	if sys.NumContexts() != 0 || game.IsBlocked() {
+		t.Fatal("removed context should not block the handler")
+	}
+}
@@ -48,6 +48,12 @@ type SimulatedAction struct {
 // Duration for key press with modifiers it will return the lowest duration of all key presses.
 // Use HasDuration() predicate to know whether there is a duration associated
 // with the event to distinguish between 0 duration and lack of duration info.
+//
+// Delta, Scale and Rotation are set for the touch gestures.
+// Delta is a movement since the previous frame for drag and pan gestures
+// and the whole swipe vector for the swipe gestures.
+// Scale is a fingers distance change factor since the previous frame (pinch).
+// Rotation is a fingers angle change since the previous frame (rotate).
 type EventInfo struct {
 	kind        keyKind
 	hasPos      bool
@@ -56,6 +62,10 @@ type EventInfo struct {
 	Duration int
 	Pos      Vec
 	StartPos Vec
+
+	Delta    Vec
+	Scale    float64
+	Rotation float64
 }
 
 // HasPos reports whether this event has a position associated with it.
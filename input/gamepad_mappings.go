@@ -0,0 +1,127 @@
+package input
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// GamepadMapping is a single SDL_GameControllerDB entry.
+//
+// The mapping translates the raw device buttons and axes
+// into the standard gamepad layout used by the gamepad keys (like KeyGamepadA).
+type GamepadMapping struct {
+	// GUID is an SDL device GUID, see Handler.GamepadSDLID.
+	// It can also be "xinput" for the generic XInput devices mapping.
+	GUID string
+
+	Name string
+
+	// Platform is a platform this mapping is defined for, like "Windows" or "Linux".
+	// An empty platform means "any platform".
+	Platform string
+
+	// Bindings maps the standard layout elements (like "a" or "leftx")
+	// to the raw device inputs (like "b0" or "a1").
+	Bindings map[string]string
+}
+
+// ParseGamepadMappings parses the SDL_GameControllerDB formatted data (gamecontrollerdb.txt).
+//
+// Empty lines and lines starting with # are ignored.
+func ParseGamepadMappings(data string) ([]GamepadMapping, error) {
+	var result []GamepadMapping
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m, err := parseGamepadMapping(line)
+		if err != nil {
+			return nil, errors.New("line " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func parseGamepadMapping(line string) (GamepadMapping, error) {
+	parts := strings.Split(strings.TrimSuffix(line, ","), ",")
+	if len(parts) < 3 {
+		return GamepadMapping{}, errors.New("expected at least 3 comma-separated fields")
+	}
+	m := GamepadMapping{
+		GUID:     parts[0],
+		Name:     parts[1],
+		Bindings: make(map[string]string, len(parts)-2),
+	}
+	// Ebitengine doesn't validate the GUID format: the upstream database
+	// has special entries like "xinput" that should be accepted as well.
+	if m.GUID == "" {
+		return m, errors.New("empty GUID")
+	}
+	if m.Name == "" {
+		return m, errors.New("empty gamepad name")
+	}
+	for _, p := range parts[2:] {
+		key, value, ok := strings.Cut(p, ":")
+		if !ok || key == "" {
+			return m, errors.New("invalid mapping element: " + p)
+		}
+		if key == "platform" {
+			m.Platform = value
+			continue
+		}
+		m.Bindings[key] = value
+	}
+	return m, nil
+}
+
+// LoadGamepadMappings adds the SDL_GameControllerDB formatted mappings.
+//
+// By default, the community database bundled with Ebitengine is used
+// (https://github.com/gabomdq/SDL_GameControllerDB).
+// The new mappings override the existing ones with the same GUID,
+// so they can be used to add the devices it doesn't cover yet or fix their mappings.
+//
+// A typical use case is loading a user-provided gamecontrollerdb.txt file
+// after the System.Init call; a malformed file is reported as an error.
+//
+// The mappings take effect immediately, even for the connected gamepads.
+// On platforms where gamepad mappings are not managed by Ebitengine (like browsers),
+// this method is a no-op.
+func (sys *System) LoadGamepadMappings(data string) error {
+	mappings, err := ParseGamepadMappings(data)
+	if err != nil {
+		return err
+	}
+	if len(mappings) == 0 {
+		return nil
+	}
+	updated, err := ebiten.UpdateStandardGamepadLayoutMappings(data)
+	if err != nil {
+		return err
+	}
+	if updated {
+		// Force the gamepad models re-detection:
+		// the standard layout could become available.
+		for i := range sys.gamepadInfo {
+			sys.gamepadInfo[i].modelName = ""
+		}
+	}
+	return nil
+}
+
+// GamepadSDLID returns the SDL GUID of the handler's gamepad.
+// This ID is used as a key in the gamecontrollerdb.txt mappings.
+//
+// It returns an empty string if there is no such gamepad
+// or if the platform doesn't support it (browsers and mobiles).
+func (h *Handler) GamepadSDLID() string {
+	if !h.GamepadConnected() {
+		return ""
+	}
+	return ebiten.GamepadSDLID(h.gamepadID())
+}
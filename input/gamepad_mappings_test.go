@@ -0,0 +1,70 @@
+package input
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGamepadMappings(t *testing.T) {
+	data := strings.Join([]string{
+		"# A comment line.",
+		"",
+		"030000005e0400008e02000000000000,Test Pad,a:b0,b:b1,leftx:a0,dpup:h0.1,+lefty:+a1,platform:Linux,",
+		"050000005e040000e0020000ffff0000,Other Pad,a:b1,b:b0",
+		"xinput,XInput Controller,a:b0,b:b1,back:b6,platform:Windows,",
+	}, "\n")
+	mappings, err := ParseGamepadMappings(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mappings) != 3 {
+		t.Fatalf("expected 3 mappings, got %d", len(mappings))
+	}
+
+	m := mappings[0]
+	if m.GUID != "030000005e0400008e02000000000000" || m.Name != "Test Pad" || m.Platform != "Linux" {
+		t.Fatalf("unexpected mapping header: %q %q %q", m.GUID, m.Name, m.Platform)
+	}
+	bindings := map[string]string{
+		"a":      "b0",
+		"b":      "b1",
+		"leftx":  "a0",
+		"dpup":   "h0.1",
+		"+lefty": "+a1",
+	}
+	if len(m.Bindings) != len(bindings) {
+		t.Fatalf("unexpected bindings: %v", m.Bindings)
+	}
+	for k, v := range bindings {
+		if m.Bindings[k] != v {
+			t.Fatalf("bindings[%q]: have %q, want %q", k, m.Bindings[k], v)
+		}
+	}
+	if mappings[1].Platform != "" {
+		t.Fatalf("unexpected platform: %q", mappings[1].Platform)
+	}
+	if m := mappings[2]; m.GUID != "xinput" || m.Name != "XInput Controller" || m.Platform != "Windows" || len(m.Bindings) != 3 {
+		t.Fatalf("unexpected xinput mapping: %+v", m)
+	}
+}
+
+func TestParseGamepadMappingsError(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"030000005e0400008e02000000000000,Test Pad", "line 1: expected at least 3 comma-separated fields"},
+		{"\n,Test Pad,a:b0", "line 2: empty GUID"},
+		{"030000005e0400008e02000000000000,,a:b0", "line 1: empty gamepad name"},
+		{"030000005e0400008e02000000000000,Test Pad,a", "line 1: invalid mapping element: a"},
+	}
+	for _, test := range tests {
+		_, err := ParseGamepadMappings(test.input)
+		if err == nil {
+			t.Fatalf("%q: expected an error", test.input)
+		}
+		if err.Error() != test.err {
+			t.Fatalf("%q: have %q error, want %q", test.input, err.Error(), test.err)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package input
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/quasilyte/ge/gesignal"
+)
+
+// GamepadEvent describes a gamepad hot-plug event.
+type GamepadEvent struct {
+	// Device is an Ebitengine gamepad ID.
+	Device ebiten.GamepadID
+
+	// PlayerID is a player slot this device is assigned to.
+	// It's -1 if the device is not assigned to any player.
+	PlayerID int
+
+	// Name is a gamepad model name.
+	// It can be empty for the disconnected unassigned devices.
+	Name string
+}
+
+// Player slots.
+//
+// Every player ID (see NewHandler) is a slot that can be bound to a gamepad device.
+// By default, the player N uses the gamepad device N.
+//
+// When a gamepad is disconnected, its slot remembers the device (SDL GUID).
+// When the same kind of device is connected again, it's bound to the same slot,
+// even if Ebitengine gives it a new ID.
+//
+// Use AssignGamepad or GamepadJoiner to bind the devices to the player slots explicitly.
+
+// AssignGamepad binds the gamepad device to the player slot.
+// If this device is bound to another slot, that slot is released.
+func (sys *System) AssignGamepad(playerID uint8, id ebiten.GamepadID) {
+	if other := sys.gamepadPlayer(id); other != -1 {
+		sys.ReleaseGamepad(uint8(other))
+	}
+	info := &sys.gamepadInfo[playerID]
+	info.assigned = true
+	info.device = id
+	info.connected = containsGamepadID(sys.gamepadIDs, id)
+	info.sdlID = ""
+	if info.connected {
+		info.sdlID = ebiten.GamepadSDLID(id)
+	}
+	// Force the gamepad model re-detection.
+	info.modelName = ""
+}
+
+// ReleaseGamepad unbinds the gamepad device from the player slot.
+func (sys *System) ReleaseGamepad(playerID uint8) {
+	info := &sys.gamepadInfo[playerID]
+	info.assigned = false
+	info.connected = false
+	info.device = -1
+	info.sdlID = ""
+	info.modelName = ""
+}
+
+// ReleaseGamepads unbinds the gamepad devices from all player slots.
+func (sys *System) ReleaseGamepads() {
+	for i := range sys.gamepadInfo {
+		sys.ReleaseGamepad(uint8(i))
+	}
+}
+
+// PlayerGamepad returns the gamepad device bound to the player slot.
+// The second result is false if there is no such device or it's disconnected.
+func (sys *System) PlayerGamepad(playerID uint8) (ebiten.GamepadID, bool) {
+	info := &sys.gamepadInfo[playerID]
+	return info.device, info.connected
+}
+
+// GamepadJoiner implements the "press start to join" flow.
+//
+// When an unassigned gamepad join key is pressed, that gamepad
+// is bound to the first free player slot.
+//
+// Use System.NewGamepadJoiner to create the joiner.
+type GamepadJoiner struct {
+	sys        *System
+	key        Key
+	maxPlayers int
+
+	EventJoined gesignal.Event[GamepadEvent]
+}
+
+// NewGamepadJoiner creates a joiner for up to maxPlayers players.
+// The joinKey should be a gamepad key, like KeyGamepadStart.
+//
+// All player slots are released, so the players can join in any order.
+func (sys *System) NewGamepadJoiner(joinKey Key, maxPlayers int) *GamepadJoiner {
+	if joinKey.kind != keyGamepad {
+		panic("join key should be a gamepad button key")
+	}
+	if maxPlayers > len(sys.gamepadInfo) {
+		panic("too many players for the joiner")
+	}
+	sys.ReleaseGamepads()
+	return &GamepadJoiner{
+		sys:        sys,
+		key:        joinKey,
+		maxPlayers: maxPlayers,
+	}
+}
+
+// NumJoined reports how many player slots are bound to the gamepads.
+func (j *GamepadJoiner) NumJoined() int {
+	n := 0
+	for i := 0; i < j.maxPlayers; i++ {
+		if j.sys.gamepadInfo[i].assigned {
+			n++
+		}
+	}
+	return n
+}
+
+// Update checks the unassigned gamepads join key state.
+// Call it once per frame after the System.Update.
+func (j *GamepadJoiner) Update() {
+	for _, id := range j.sys.gamepadIDs {
+		if j.sys.gamepadPlayer(id) != -1 {
+			continue
+		}
+		if !gamepadDeviceButtonIsJustPressed(id, j.key.code) {
+			continue
+		}
+		playerID := j.freeSlot()
+		if playerID == -1 {
+			return
+		}
+		j.sys.AssignGamepad(uint8(playerID), id)
+		j.EventJoined.Emit(GamepadEvent{
+			Device:   id,
+			PlayerID: playerID,
+			Name:     ebiten.GamepadName(id),
+		})
+	}
+}
+
+func (j *GamepadJoiner) freeSlot() int {
+	for i := 0; i < j.maxPlayers; i++ {
+		if !j.sys.gamepadInfo[i].assigned {
+			return i
+		}
+	}
+	return -1
+}
+
+func gamepadDeviceButtonIsJustPressed(id ebiten.GamepadID, code int) bool {
+	if ebiten.IsStandardGamepadLayoutAvailable(id) {
+		return inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButton(code))
+	}
+	return inpututil.IsGamepadButtonJustPressed(id, ebiten.GamepadButton(code))
+}
+
+func (sys *System) initGamepadSlots() {
+	for i := range sys.gamepadInfo {
+		info := &sys.gamepadInfo[i]
+		info.assigned = true
+		info.device = ebiten.GamepadID(i)
+	}
+}
+
+// gamepadDescriber returns the device name and SDL GUID.
+type gamepadDescriber func(id ebiten.GamepadID) (string, string)
+
+func describeGamepad(id ebiten.GamepadID) (string, string) {
+	return ebiten.GamepadName(id), ebiten.GamepadSDLID(id)
+}
+
+// syncGamepadSlots handles the connected and disconnected devices.
+func (sys *System) syncGamepadSlots(ids []ebiten.GamepadID, describe gamepadDescriber) {
+	for _, id := range sys.prevGamepadIDs {
+		if containsGamepadID(ids, id) {
+			continue
+		}
+		e := GamepadEvent{Device: id, PlayerID: sys.gamepadPlayer(id)}
+		if e.PlayerID != -1 {
+			info := &sys.gamepadInfo[e.PlayerID]
+			info.connected = false
+			e.Name = info.modelName
+		}
+		sys.EventGamepadDisconnected.Emit(e)
+	}
+
+	for _, id := range ids {
+		if containsGamepadID(sys.prevGamepadIDs, id) {
+			continue
+		}
+		name, sdlID := describe(id)
+		sys.EventGamepadConnected.Emit(GamepadEvent{
+			Device:   id,
+			PlayerID: sys.reconnectGamepad(id, sdlID),
+			Name:     name,
+		})
+	}
+
+	sys.prevGamepadIDs = append(sys.prevGamepadIDs[:0], ids...)
+}
+
+// reconnectGamepad binds the new device to a slot that is waiting for it.
+// It returns the slot index or -1.
+func (sys *System) reconnectGamepad(id ebiten.GamepadID, sdlID string) int {
+	bind := func(i int) int {
+		info := &sys.gamepadInfo[i]
+		info.device = id
+		info.connected = true
+		info.sdlID = sdlID
+		info.modelName = ""
+		return i
+	}
+	// Prefer the slot that was used by the same kind of device.
+	if sdlID != "" {
+		for i := range sys.gamepadInfo {
+			info := &sys.gamepadInfo[i]
+			if info.assigned && !info.connected && info.sdlID == sdlID {
+				return bind(i)
+			}
+		}
+	}
+	for i := range sys.gamepadInfo {
+		info := &sys.gamepadInfo[i]
+		if info.assigned && !info.connected && info.device == id {
+			return bind(i)
+		}
+	}
+	return -1
+}
+
+// gamepadPlayer returns the slot the connected device is bound to or -1.
+func (sys *System) gamepadPlayer(id ebiten.GamepadID) int {
+	for i := range sys.gamepadInfo {
+		info := &sys.gamepadInfo[i]
+		if info.connected && info.device == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsGamepadID(ids []ebiten.GamepadID, id ebiten.GamepadID) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
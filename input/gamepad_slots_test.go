@@ -0,0 +1,89 @@
+package input
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type testConnection struct{}
+
+func (testConnection) IsDisposed() bool { return false }
+
+func TestGamepadSlots(t *testing.T) {
+	sys := System{gamepadInfo: make([]gamepadInfo, 4)}
+	sys.initGamepadSlots()
+
+	var events []string
+	record := func(kind string) func(e GamepadEvent) {
+		return func(e GamepadEvent) {
+			events = append(events, fmt.Sprintf("%s %d %d %s", kind, e.Device, e.PlayerID, e.Name))
+		}
+	}
+	sys.EventGamepadConnected.Connect(testConnection{}, record("connected"))
+	sys.EventGamepadDisconnected.Connect(testConnection{}, record("disconnected"))
+
+	describe := func(id ebiten.GamepadID) (string, string) {
+		switch id {
+		case 0, 2:
+			return "pad_a", "guid_a"
+		default:
+			return "pad_b", "guid_b"
+		}
+	}
+
+	steps := []struct {
+		ids    []ebiten.GamepadID
+		events []string
+	}{
+		{
+			ids:    []ebiten.GamepadID{0, 1},
+			events: []string{"connected 0 0 pad_a", "connected 1 1 pad_b"},
+		},
+		{
+			ids:    []ebiten.GamepadID{0, 1},
+			events: nil,
+		},
+		{
+			ids:    []ebiten.GamepadID{1},
+			events: []string{"disconnected 0 0 "},
+		},
+		{
+			// The same kind of device is connected with a different ID,
+			// it takes the first player slot back.
+			ids:    []ebiten.GamepadID{1, 2},
+			events: []string{"connected 2 0 pad_a"},
+		},
+		{
+			// There are no free slots for this device.
+			ids:    []ebiten.GamepadID{1, 2, 5},
+			events: []string{"connected 5 -1 pad_b"},
+		},
+	}
+
+	for i, step := range steps {
+		events = events[:0]
+		sys.syncGamepadSlots(step.ids, describe)
+		if len(events) != len(step.events) {
+			t.Fatalf("step[%d]: have %q, want %q", i, events, step.events)
+		}
+		for j := range events {
+			if events[j] != step.events[j] {
+				t.Fatalf("step[%d]: have %q, want %q", i, events, step.events)
+			}
+		}
+	}
+
+	if id, ok := sys.PlayerGamepad(0); !ok || id != 2 {
+		t.Fatalf("player 0: have (%v, %v), want (2, true)", id, ok)
+	}
+	if _, ok := sys.PlayerGamepad(3); ok {
+		t.Fatal("player 3 should not have a connected gamepad")
+	}
+
+	sys.ReleaseGamepads()
+	if sys.gamepadPlayer(1) != -1 {
+		t.Fatal("released gamepad is still bound to a player")
+	}
+}
@@ -0,0 +1,72 @@
+package input
+
+import (
+	"strings"
+)
+
+// GamepadStyle is a gamepad buttons labeling style.
+// It can be used to select the matching button prompt glyphs.
+type GamepadStyle uint8
+
+const (
+	GamepadStyleGeneric GamepadStyle = iota
+	GamepadStyleXbox
+	GamepadStylePlayStation
+	GamepadStyleNintendo
+)
+
+func (s GamepadStyle) String() string {
+	switch s {
+	case GamepadStyleXbox:
+		return "xbox"
+	case GamepadStylePlayStation:
+		return "playstation"
+	case GamepadStyleNintendo:
+		return "nintendo"
+	default:
+		return "generic"
+	}
+}
+
+// GamepadStyle returns the detected handler's gamepad labeling style.
+// If the gamepad model is not recognized, GamepadStyleGeneric is returned.
+//
+// There should be at least one call to the System.Update() before this function
+// can return the correct results.
+func (h *Handler) GamepadStyle() GamepadStyle {
+	if !h.GamepadConnected() {
+		return GamepadStyleGeneric
+	}
+	return h.gamepadInfo().style
+}
+
+// guessGamepadStyle uses the SDL GUID vendor ID and the device name to detect the gamepad style.
+func guessGamepadStyle(name, sdlID string) GamepadStyle {
+	// The SDL GUID encodes the USB vendor ID as a little-endian
+	// 16-bit value at the bytes 4-5 (hex chars 8-11).
+	if len(sdlID) == 32 {
+		switch strings.ToLower(sdlID[8:12]) {
+		case "5e04": // Microsoft
+			return GamepadStyleXbox
+		case "4c05": // Sony
+			return GamepadStylePlayStation
+		case "7e05": // Nintendo
+			return GamepadStyleNintendo
+		}
+	}
+
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "xbox") || strings.Contains(name, "xinput"):
+		return GamepadStyleXbox
+	case strings.Contains(name, "playstation") || strings.Contains(name, "dualshock") ||
+		strings.Contains(name, "dualsense") || strings.Contains(name, "ps3") ||
+		strings.Contains(name, "ps4") || strings.Contains(name, "ps5"):
+		return GamepadStylePlayStation
+	case strings.Contains(name, "nintendo") || strings.Contains(name, "switch") ||
+		strings.Contains(name, "joy-con"):
+		return GamepadStyleNintendo
+	default:
+		return GamepadStyleGeneric
+	}
+}
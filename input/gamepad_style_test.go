@@ -0,0 +1,28 @@
+package input
+
+import (
+	"testing"
+)
+
+func TestGuessGamepadStyle(t *testing.T) {
+	tests := []struct {
+		name  string
+		sdlID string
+		want  GamepadStyle
+	}{
+		{"Xbox 360 Controller", "", GamepadStyleXbox},
+		{"Some Controller", "030000005e0400008e02000000000000", GamepadStyleXbox},
+		{"Wireless Controller", "030000004c050000cc09000000000000", GamepadStylePlayStation},
+		{"DualSense Wireless Controller", "", GamepadStylePlayStation},
+		{"Nintendo Switch Pro Controller", "", GamepadStyleNintendo},
+		{"Controller", "030000007e0500000920000000000000", GamepadStyleNintendo},
+		{"Micront", "", GamepadStyleGeneric},
+		{"", "", GamepadStyleGeneric},
+	}
+	for _, test := range tests {
+		have := guessGamepadStyle(test.name, test.sdlID)
+		if have != test.want {
+			t.Fatalf("guess(%q, %q): have %s, want %s", test.name, test.sdlID, have, test.want)
+		}
+	}
+}
@@ -21,6 +21,11 @@ type Handler struct {
 	keymap Keymap
 	sys    *System
 
+	actionConfigs map[Action]ActionConfig
+
+	contextConsume bool
+	contextModal   bool
+
 	// GamepadDeadzone is the magnitude of a controller stick movements
 	// the handler can receive before registering it as an input.
 	//
@@ -49,12 +54,7 @@ func (h *Handler) Remap(keymap Keymap) {
 // There should be at least one call to the System.Update() before this function
 // can return the correct results.
 func (h *Handler) GamepadConnected() bool {
-	for _, id := range h.sys.gamepadIDs {
-		if id == ebiten.GamepadID(h.id) {
-			return true
-		}
-	}
-	return false
+	return h.sys.gamepadIsConnected(h.id)
 }
 
 // TouchEventsEnabled reports whether this handler can receive screen touch events.
@@ -62,6 +62,12 @@ func (h *Handler) TouchEventsEnabled() bool {
 	return h.sys.touchEnabled
 }
 
+// ActiveTouches returns all active screen touches.
+// The returned slice is only valid during the current frame and should not be modified.
+func (h *Handler) ActiveTouches() []TouchInfo {
+	return h.sys.touch.touches
+}
+
 // CursorPos returns the current mouse cursor position on the screen.
 func (h *Handler) CursorPos() Vec {
 	return h.sys.cursorPos
@@ -159,6 +165,23 @@ func (h *Handler) ActionKeyNames(action Action, mask DeviceKind) []string {
 	return result
 }
 
+// ActionKeys is like ActionKeyNames, but returns the keys themselves.
+// It can be used to render the key glyphs instead of their names.
+func (h *Handler) ActionKeys(action Action, mask DeviceKind) []Key {
+	keys, ok := h.keymap[action]
+	if !ok {
+		return nil
+	}
+	result := make([]Key, 0, len(keys))
+	for _, k := range keys {
+		if !h.keyIsEnabled(k, mask) {
+			continue
+		}
+		result = append(result, k)
+	}
+	return result
+}
+
 func (h *Handler) keyIsEnabled(k Key, mask DeviceKind) bool {
 	switch k.kind {
 	case keyKeyboardWithCtrlShift:
@@ -201,19 +224,13 @@ func (h *Handler) JustReleasedActionInfo(action Action) (EventInfo, bool) {
 		return EventInfo{}, false
 	}
 	for _, k := range keys {
+		if h.keyIsConsumed(k) {
+			continue
+		}
 		if !h.keyIsJustReleased(k) {
 			continue
 		}
-		// TODO: maybe move this EventInfo initialization code to a function?
-		// It look like it's the same code in every *ActionInfo method.
-		// We don't need the StartPos here yet, because touch events
-		// are not handled in release events, but that's just a minutiae.
-		var info EventInfo
-		info.kind = k.kind
-		info.hasPos = keyHasPos(k.kind)
-		info.Pos = h.getKeyPos(k)
-		info.StartPos = h.getKeyStartPos(k)
-		return info, true
+		return h.makeEventInfo(k), true
 	}
 	return EventInfo{}, false
 }
@@ -249,6 +266,9 @@ func (h *Handler) ActionIsJustReleased(action Action) bool {
 		return false
 	}
 	for _, k := range keys {
+		if h.keyIsConsumed(k) {
+			continue
+		}
 		if h.keyIsJustReleased(k) {
 			return true
 		}
@@ -268,20 +288,18 @@ func (h *Handler) JustPressedActionInfo(action Action) (EventInfo, bool) {
 		return EventInfo{}, false
 	}
 	for _, k := range keys {
+		if h.keyIsConsumed(k) {
+			continue
+		}
 		if info, status := h.pressedSimulatedKeyInfo(true, k); status == bool3true {
 			return info, true
 		}
 		if !h.keyIsJustPressed(k) {
 			continue
 		}
-		var info EventInfo
-		info.kind = k.kind
-		info.hasPos = keyHasPos(k.kind)
-		info.Pos = h.getKeyPos(k)
-		info.StartPos = h.getKeyStartPos(k)
-		return info, true
+		return h.makeEventInfo(k), true
 	}
-	if h.sys.hasSimulatedActions {
+	if h.sys.hasSimulatedActions && !h.IsBlocked() {
 		info, status := h.pressedSimulatedKeyInfo(true, Key{
 			code: int(action),
 			kind: keySimulated,
@@ -303,17 +321,16 @@ func (h *Handler) PressedActionInfo(action Action) (EventInfo, bool) {
 		return EventInfo{}, false
 	}
 	for _, k := range keys {
+		if h.keyIsConsumed(k) {
+			continue
+		}
 		if info, status := h.pressedSimulatedKeyInfo(false, k); status == bool3true {
 			return info, true
 		}
 		if !h.keyIsPressed(k) {
 			continue
 		}
-		var info EventInfo
-		info.kind = k.kind
-		info.hasPos = keyHasPos(k.kind)
-		info.Pos = h.getKeyPos(k)
-		info.StartPos = h.getKeyStartPos(k)
+		info := h.makeEventInfo(k)
 		info.hasDuration = keyHasDuration(k.kind)
 		info.Duration = h.getKeyPressDuration(k)
 		return info, true
@@ -330,6 +347,9 @@ func (h *Handler) ActionIsJustPressed(action Action) bool {
 		return false
 	}
 	for _, k := range keys {
+		if h.keyIsConsumed(k) {
+			continue
+		}
 		if len(h.sys.simulatedEvents) != 0 {
 			// We want to avoid a situation when simulated input
 			// things that the key is still being pressed and then
@@ -346,7 +366,7 @@ func (h *Handler) ActionIsJustPressed(action Action) bool {
 			return true
 		}
 	}
-	if h.sys.hasSimulatedActions {
+	if h.sys.hasSimulatedActions && !h.IsBlocked() {
 		_, isPressed := h.pressedSimulatedKeyInfo(true, Key{
 			code: int(action),
 			kind: keySimulated,
@@ -365,6 +385,9 @@ func (h *Handler) ActionIsPressed(action Action) bool {
 		return false
 	}
 	for _, k := range keys {
+		if h.keyIsConsumed(k) {
+			continue
+		}
 		if len(h.sys.simulatedEvents) != 0 && h.simulatedKeyIsPressed(k) {
 			return true
 		}
@@ -372,7 +395,7 @@ func (h *Handler) ActionIsPressed(action Action) bool {
 			return true
 		}
 	}
-	if h.sys.hasSimulatedActions {
+	if h.sys.hasSimulatedActions && !h.IsBlocked() {
 		return h.simulatedKeyIsPressed(Key{
 			code: int(action),
 			kind: keySimulated,
@@ -381,6 +404,23 @@ func (h *Handler) ActionIsPressed(action Action) bool {
 	return false
 }
 
+func (h *Handler) makeEventInfo(k Key) EventInfo {
+	var info EventInfo
+	info.kind = k.kind
+	info.hasPos = keyHasPos(k.kind)
+	switch k.kind {
+	case keyTouch:
+		h.sys.touch.fillEventInfo(&info, touchCode(k.code))
+	case keyTouchDrag:
+		info.Pos = h.sys.touch.dragPos
+		info.StartPos = h.sys.touch.startPos
+		info.Delta = vecSub(h.sys.touch.dragPos, h.sys.touch.prevDragPos)
+	default:
+		info.Pos = h.getKeyPos(k)
+	}
+	return info
+}
+
 func (h *Handler) keyIsJustReleased(k Key) bool {
 	// Several key kinds are not handled here.
 	// TODO: extend the supported key kinds list?
@@ -423,15 +463,9 @@ func (h *Handler) ebitenKeyIsPressedOrJustReleased(k ebiten.Key) bool {
 func (h *Handler) keyIsJustPressed(k Key) bool {
 	switch k.kind {
 	case keyTouch:
-		if k.code == int(touchTap) {
-			return h.sys.touchHasTap
-		}
-		if k.code == int(touchLongTap) {
-			return h.sys.touchHasLongTap
-		}
-		return false
+		return h.sys.touch.isJustPressed(touchCode(k.code))
 	case keyTouchDrag:
-		return h.sys.touchJustHadDrag
+		return h.sys.touch.justHadDrag
 	case keyGamepad:
 		return h.gamepadKeyIsJustPressed(k)
 	case keyGamepadLeftStick:
@@ -469,24 +503,11 @@ func (h *Handler) keyIsJustPressed(k Key) bool {
 	}
 }
 
-func (h *Handler) getKeyStartPos(k Key) Vec {
-	var result Vec
-	switch k.kind {
-	case keyTouchDrag:
-		result = h.sys.touchStartPos
-	}
-	return result
-}
-
 func (h *Handler) getKeyPos(k Key) Vec {
 	var result Vec
 	switch k.kind {
 	case keyMouse, keyMouseWithCtrl, keyMouseWithShift, keyMouseWithCtrlShift:
 		result = h.sys.cursorPos
-	case keyTouch:
-		result = h.sys.touchTapPos
-	case keyTouchDrag:
-		result = h.sys.touchDragPos
 	case keyWheel:
 		result = h.sys.wheel
 	case keyGamepadStickMotion:
@@ -520,15 +541,9 @@ func (h *Handler) getKeyPressDuration(k Key) int {
 func (h *Handler) keyIsPressed(k Key) bool {
 	switch k.kind {
 	case keyTouch:
-		if k.code == int(touchTap) {
-			return h.sys.touchHasTap
-		}
-		if k.code == int(touchLongTap) {
-			return h.sys.touchHasLongTap
-		}
-		return false
+		return h.sys.touch.isPressed(touchCode(k.code))
 	case keyTouchDrag:
-		return h.sys.touchHasDrag
+		return h.sys.touch.hasDrag
 	case keyGamepad:
 		return h.gamepadKeyIsPressed(k)
 	case keyGamepadLeftStick:
@@ -632,15 +647,21 @@ func (h *Handler) wheelIsJustPressed(code wheelCode) bool {
 }
 
 func (h *Handler) gamepadKeyIsJustReleased(k Key) bool {
+	if h.virtualButtonWasPressed(k.code) && !h.virtualButtonIsPressed(k.code) {
+		return true
+	}
 	if h.gamepadInfo().model == gamepadStandard {
-		return inpututil.IsStandardGamepadButtonJustReleased(ebiten.GamepadID(h.id), ebiten.StandardGamepadButton(k.code))
+		return inpututil.IsStandardGamepadButtonJustReleased(h.gamepadID(), ebiten.StandardGamepadButton(k.code))
 	}
-	return inpututil.IsGamepadButtonJustReleased(ebiten.GamepadID(h.id), h.mappedGamepadKey(k.code))
+	return inpututil.IsGamepadButtonJustReleased(h.gamepadID(), h.mappedGamepadKey(k.code))
 }
 
 func (h *Handler) gamepadKeyIsJustPressed(k Key) bool {
+	if h.virtualButtonIsPressed(k.code) {
+		return !h.virtualButtonWasPressed(k.code)
+	}
 	if h.gamepadInfo().model == gamepadStandard {
-		return inpututil.IsStandardGamepadButtonJustPressed(ebiten.GamepadID(h.id), ebiten.StandardGamepadButton(k.code))
+		return inpututil.IsStandardGamepadButtonJustPressed(h.gamepadID(), ebiten.StandardGamepadButton(k.code))
 	}
 	if h.gamepadInfo().model == gamepadFirefoxXinput {
 		if isDPadButton(k.code) {
@@ -656,12 +677,15 @@ func (h *Handler) gamepadKeyIsJustPressed(k Key) bool {
 				h.bumperIsActive(h.gamepadInfo().axisValues[5])
 		}
 	}
-	return inpututil.IsGamepadButtonJustPressed(ebiten.GamepadID(h.id), h.mappedGamepadKey(k.code))
+	return inpututil.IsGamepadButtonJustPressed(h.gamepadID(), h.mappedGamepadKey(k.code))
 }
 
 func (h *Handler) gamepadKeyIsPressed(k Key) bool {
+	if h.virtualButtonIsPressed(k.code) {
+		return true
+	}
 	if h.gamepadInfo().model == gamepadStandard {
-		return ebiten.IsStandardGamepadButtonPressed(ebiten.GamepadID(h.id), ebiten.StandardGamepadButton(k.code))
+		return ebiten.IsStandardGamepadButtonPressed(h.gamepadID(), ebiten.StandardGamepadButton(k.code))
 	}
 	if h.gamepadInfo().model == gamepadFirefoxXinput {
 		if isDPadButton(k.code) {
@@ -674,7 +698,15 @@ func (h *Handler) gamepadKeyIsPressed(k Key) bool {
 			return h.bumperIsActive(h.gamepadInfo().axisValues[5])
 		}
 	}
-	return ebiten.IsGamepadButtonPressed(ebiten.GamepadID(h.id), h.mappedGamepadKey(k.code))
+	return ebiten.IsGamepadButtonPressed(h.gamepadID(), h.mappedGamepadKey(k.code))
+}
+
+func (h *Handler) virtualButtonIsPressed(code int) bool {
+	return h.gamepadInfo().virtualButtons&(1<<code) != 0
+}
+
+func (h *Handler) virtualButtonWasPressed(code int) bool {
+	return h.gamepadInfo().prevVirtualButtons&(1<<code) != 0
 }
 
 func (h *Handler) gamepadStickIsActive(code stickCode, vec Vec) bool {
@@ -767,6 +799,16 @@ func (h *Handler) gamepadInfo() *gamepadInfo {
 	return &h.sys.gamepadInfo[h.id]
 }
 
+// gamepadID returns the device bound to the handler's player slot.
+// For the disconnected slots, it returns an invalid ID that is ignored by Ebitengine.
+func (h *Handler) gamepadID() ebiten.GamepadID {
+	info := h.gamepadInfo()
+	if !info.connected {
+		return -1
+	}
+	return info.device
+}
+
 func (h *Handler) mappedGamepadKey(keyCode int) ebiten.GamepadButton {
 	b := ebiten.StandardGamepadButton(keyCode)
 	switch h.gamepadInfo().model {
@@ -24,12 +24,27 @@ func guessGamepadModel(s string) gamepadModel {
 }
 
 type gamepadInfo struct {
+	// The player slot state, see AssignGamepad.
+	device    ebiten.GamepadID
+	assigned  bool
+	connected bool
+	sdlID     string
+
 	model     gamepadModel
 	modelName string
+	style     GamepadStyle
+
+	lastActiveTick uint64
 
 	axisCount      int
 	axisValues     [8]float64
 	prevAxisValues [8]float64
+
+	// Virtual controls state; it's a bit set of StandardGamepadButton values.
+	virtualButtons     uint32
+	prevVirtualButtons uint32
+
+	rumble rumbleState
 }
 
 func isDPadButton(code int) bool {
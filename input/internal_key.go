@@ -28,6 +28,15 @@ const (
 	touchTap
 	touchLongTap
 	touchDrag
+	touchDoubleTap
+	touchSwipe
+	touchSwipeUp
+	touchSwipeRight
+	touchSwipeDown
+	touchSwipeLeft
+	touchPinch
+	touchPan
+	touchRotate
 )
 
 type wheelCode int
@@ -135,8 +135,17 @@ var allKeys = []Key{
 	KeySpace,
 	KeyT,
 	KeyTab,
+	KeyTouchDoubleTap,
 	KeyTouchDrag,
 	KeyTouchLongTap,
+	KeyTouchPan,
+	KeyTouchPinch,
+	KeyTouchRotate,
+	KeyTouchSwipe,
+	KeyTouchSwipeDown,
+	KeyTouchSwipeLeft,
+	KeyTouchSwipeRight,
+	KeyTouchSwipeUp,
 	KeyTouchTap,
 	KeyU,
 	KeyUp,
@@ -45,6 +45,23 @@ func (k Key) String() string {
 	return name
 }
 
+// Device returns the input device kind this key belongs to.
+// It returns 0 for the keys that are not bound to any specific device (like the simulated keys).
+func (k Key) Device() DeviceKind {
+	switch k.kind {
+	case keyKeyboard, keyKeyboardWithCtrl, keyKeyboardWithShift, keyKeyboardWithCtrlShift:
+		return KeyboardDevice
+	case keyMouse, keyMouseWithCtrl, keyMouseWithShift, keyMouseWithCtrlShift, keyWheel:
+		return MouseDevice
+	case keyGamepad, keyGamepadLeftStick, keyGamepadRightStick, keyGamepadStickMotion:
+		return GamepadDevice
+	case keyTouch, keyTouchDrag:
+		return TouchDevice
+	default:
+		return 0
+	}
+}
+
 type KeyModifier uint8
 
 const (
@@ -112,6 +129,30 @@ var (
 	KeyTouchLongTap = Key{code: int(touchLongTap), kind: keyTouch, name: "touch_long_tap"}
 
 	KeyTouchDrag = Key{kind: keyTouchDrag, name: "touch_drag"}
+
+	// Two taps at the same location that happened in a quick succession.
+	// The second tap also triggers a KeyTouchTap event.
+	KeyTouchDoubleTap = Key{code: int(touchDoubleTap), kind: keyTouch, name: "touch_double_tap"}
+
+	// A quick drag gesture that is triggered when the touch is released.
+	// The swipe vector is available as EventInfo.Delta.
+	// The direction-specific keys are triggered only for the dominant axis.
+	KeyTouchSwipe      = Key{code: int(touchSwipe), kind: keyTouch, name: "touch_swipe"}
+	KeyTouchSwipeUp    = Key{code: int(touchSwipeUp), kind: keyTouch, name: "touch_swipe_up"}
+	KeyTouchSwipeRight = Key{code: int(touchSwipeRight), kind: keyTouch, name: "touch_swipe_right"}
+	KeyTouchSwipeDown  = Key{code: int(touchSwipeDown), kind: keyTouch, name: "touch_swipe_down"}
+	KeyTouchSwipeLeft  = Key{code: int(touchSwipeLeft), kind: keyTouch, name: "touch_swipe_left"}
+
+	// Two-finger gestures.
+	// They stay pressed until one of the fingers is released.
+	// EventInfo.Pos is a center between the fingers, EventInfo.StartPos is its initial location.
+	//
+	// KeyTouchPinch reports the distance change factor as EventInfo.Scale.
+	// KeyTouchPan reports the center movement as EventInfo.Delta.
+	// KeyTouchRotate reports the angle change (in radians) as EventInfo.Rotation.
+	KeyTouchPinch  = Key{code: int(touchPinch), kind: keyTouch, name: "touch_pinch"}
+	KeyTouchPan    = Key{code: int(touchPan), kind: keyTouch, name: "touch_pan"}
+	KeyTouchRotate = Key{code: int(touchRotate), kind: keyTouch, name: "touch_rotate"}
 )
 
 // Keyboard keys.
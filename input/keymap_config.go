@@ -0,0 +1,173 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// MarshalKeymap encodes the keymap into a JSON object that maps
+// the action names to the key name lists:
+//
+//	{"Fire": ["space", "gamepad_a"], "Pause": ["escape"]}
+//
+// The action names are taken from the names map.
+// The encoding is stable: the actions are sorted by their names and the keys order is preserved.
+//
+// The key names are compatible with ParseKey.
+func MarshalKeymap(m Keymap, names map[Action]string) ([]byte, error) {
+	encoded := make(map[string][]string, len(m))
+	for action, keys := range m {
+		name, ok := names[action]
+		if !ok {
+			return nil, errors.New("no name for action #" + strconv.FormatUint(uint64(action), 10))
+		}
+		keyNames := make([]string, len(keys))
+		for i, k := range keys {
+			keyNames[i] = k.String()
+		}
+		encoded[name] = keyNames
+	}
+	return json.MarshalIndent(encoded, "", "  ")
+}
+
+// UnmarshalKeymap decodes the keymap encoded by MarshalKeymap.
+//
+// The names map should contain all actions listed in the data.
+// It's not an error if some of the actions from the names map are missing.
+// See Keymap.Merge if you want to use the default bindings for them.
+func UnmarshalKeymap(data []byte, names map[Action]string) (Keymap, error) {
+	var encoded map[string][]string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, err
+	}
+	actions := make(map[string]Action, len(names))
+	for action, name := range names {
+		actions[name] = action
+	}
+	m := make(Keymap, len(encoded))
+	for name, keyNames := range encoded {
+		action, ok := actions[name]
+		if !ok {
+			return nil, errors.New("unknown action: " + name)
+		}
+		keys := make([]Key, len(keyNames))
+		for i, keyName := range keyNames {
+			k, err := ParseKey(keyName)
+			if err != nil {
+				return nil, errors.New(name + ": " + err.Error())
+			}
+			keys[i] = k
+		}
+		m[action] = keys
+	}
+	return m, nil
+}
+
+// Merge adds the bindings of the actions that are missing in this keymap.
+// It's useful for the saved keymaps when a new action is added to the game.
+func (m Keymap) Merge(defaults Keymap) {
+	for action, keys := range defaults {
+		if _, ok := m[action]; ok {
+			continue
+		}
+		m[action] = cloneKeys(keys)
+	}
+}
+
+// Reset replaces all bindings with the defaults.
+// The keymap is modified in place, so the handlers that use it see the changes.
+func (m Keymap) Reset(defaults Keymap) {
+	for action := range m {
+		delete(m, action)
+	}
+	for action, keys := range defaults {
+		m[action] = cloneKeys(keys)
+	}
+}
+
+// ResetAction replaces the action bindings with the defaults.
+func (m Keymap) ResetAction(defaults Keymap, action Action) {
+	keys, ok := defaults[action]
+	if !ok {
+		delete(m, action)
+		return
+	}
+	m[action] = cloneKeys(keys)
+}
+
+// Rebind replaces the first action key of the given device with a new key.
+// If the action has no keys for that device, the new key is added.
+func (m Keymap) Rebind(action Action, device DeviceKind, k Key) {
+	keys := m[action]
+	for i, old := range keys {
+		if old.Device()&device != 0 {
+			keys[i] = k
+			return
+		}
+	}
+	m[action] = append(keys, k)
+}
+
+// KeyConflict describes a key that is bound to several actions.
+type KeyConflict struct {
+	Key Key
+
+	Device DeviceKind
+
+	// Actions are sorted in the ascending order.
+	Actions []Action
+}
+
+// FindConflicts reports the keys that are bound to more than one action.
+// Only the keys of the devices included into the mask are checked.
+//
+// Some conflicts can be intentional (like a "confirm" and "fire" actions
+// bound to the same key in the different game modes), so it's up to
+// the caller to decide which conflicts should be reported to the user.
+//
+// The result is sorted by the key names.
+func FindConflicts(m Keymap, mask DeviceKind) []KeyConflict {
+	bindings := make(map[Key][]Action)
+	for action, keys := range m {
+		for _, k := range keys {
+			if k.Device()&mask == 0 {
+				continue
+			}
+			bindings[k] = appendUniqueAction(bindings[k], action)
+		}
+	}
+
+	var conflicts []KeyConflict
+	for k, actions := range bindings {
+		if len(actions) < 2 {
+			continue
+		}
+		sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+		conflicts = append(conflicts, KeyConflict{
+			Key:     k,
+			Device:  k.Device(),
+			Actions: actions,
+		})
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Key.String() < conflicts[j].Key.String()
+	})
+	return conflicts
+}
+
+func appendUniqueAction(actions []Action, a Action) []Action {
+	for _, x := range actions {
+		if x == a {
+			return actions
+		}
+	}
+	return append(actions, a)
+}
+
+func cloneKeys(keys []Key) []Key {
+	cloned := make([]Key, len(keys))
+	copy(cloned, keys)
+	return cloned
+}
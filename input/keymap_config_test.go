@@ -0,0 +1,125 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeymapMarshal(t *testing.T) {
+	const (
+		actionLeft Action = iota
+		actionRight
+		actionFire
+	)
+	names := map[Action]string{
+		actionLeft:  "Left",
+		actionRight: "Right",
+		actionFire:  "Fire",
+	}
+	m := Keymap{
+		actionLeft:  {KeyLeft, KeyA, KeyGamepadLeft},
+		actionRight: {KeyRight},
+		actionFire:  {KeyWithModifier(KeyMouseLeft, ModControl), KeyWithModifier(KeyF, ModControlShift)},
+	}
+
+	data, err := MarshalKeymap(m, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "Fire": [
+    "ctrl+mouse_left_button",
+    "ctrl+shift+f"
+  ],
+  "Left": [
+    "left",
+    "a",
+    "gamepad_left"
+  ],
+  "Right": [
+    "right"
+  ]
+}`
+	if string(data) != want {
+		t.Fatalf("marshal result mismatch:\nhave: %s\nwant: %s", data, want)
+	}
+
+	decoded, err := UnmarshalKeymap(data, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, m) {
+		t.Fatalf("unmarshal result mismatch:\nhave: %v\nwant: %v", decoded, m)
+	}
+
+	if _, err := UnmarshalKeymap([]byte(`{"Jump": ["space"]}`), names); err == nil || err.Error() != "unknown action: Jump" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := UnmarshalKeymap([]byte(`{"Left": ["foo"]}`), names); err == nil || err.Error() != "Left: unknown key: foo" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := MarshalKeymap(Keymap{10: {KeyA}}, names); err == nil || err.Error() != "no name for action #10" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestKeymapConflicts(t *testing.T) {
+	const (
+		actionLeft Action = iota
+		actionRight
+		actionFire
+		actionConfirm
+	)
+	defaults := Keymap{
+		actionLeft:    {KeyLeft, KeyGamepadLeft},
+		actionRight:   {KeyRight, KeyGamepadRight},
+		actionFire:    {KeySpace, KeyGamepadA},
+		actionConfirm: {KeyEnter, KeyGamepadA},
+	}
+	m := defaults.Clone()
+
+	conflicts := FindConflicts(m, AnyDevice)
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, found %v", conflicts)
+	}
+	if c := conflicts[0]; c.Key != KeyGamepadA || c.Device != GamepadDevice || !reflect.DeepEqual(c.Actions, []Action{actionFire, actionConfirm}) {
+		t.Fatalf("unexpected conflict: %v", c)
+	}
+	if conflicts := FindConflicts(m, KeyboardDevice); len(conflicts) != 0 {
+		t.Fatalf("unexpected keyboard conflicts: %v", conflicts)
+	}
+
+	m.Rebind(actionLeft, KeyboardDevice, KeyRight)
+	if !reflect.DeepEqual(m[actionLeft], []Key{KeyRight, KeyGamepadLeft}) {
+		t.Fatalf("unexpected rebind result: %v", m[actionLeft])
+	}
+	conflicts = FindConflicts(m, KeyboardDevice)
+	if len(conflicts) != 1 || conflicts[0].Key != KeyRight {
+		t.Fatalf("unexpected keyboard conflicts: %v", conflicts)
+	}
+
+	m.Rebind(actionFire, MouseDevice, KeyMouseLeft)
+	if !reflect.DeepEqual(m[actionFire], []Key{KeySpace, KeyGamepadA, KeyMouseLeft}) {
+		t.Fatalf("unexpected rebind result: %v", m[actionFire])
+	}
+
+	m.ResetAction(defaults, actionLeft)
+	if !reflect.DeepEqual(m[actionLeft], defaults[actionLeft]) {
+		t.Fatalf("unexpected reset result: %v", m[actionLeft])
+	}
+
+	delete(m, actionConfirm)
+	m.Merge(defaults)
+	if !reflect.DeepEqual(m[actionConfirm], defaults[actionConfirm]) {
+		t.Fatalf("unexpected merge result: %v", m[actionConfirm])
+	}
+
+	m.Reset(defaults)
+	if !reflect.DeepEqual(m, defaults) {
+		t.Fatalf("unexpected reset result: %v", m)
+	}
+	m[actionLeft][0] = KeyA
+	if defaults[actionLeft][0] != KeyLeft {
+		t.Fatal("reset keymap shares the keys with the defaults")
+	}
+}
@@ -0,0 +1,120 @@
+package input
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// lastDeviceTracker records when every input device was used for the last time.
+type lastDeviceTracker struct {
+	tick uint64
+
+	keyboardTick uint64
+	mouseTick    uint64
+	touchTick    uint64
+
+	prevCursorPos Vec
+
+	keys    []ebiten.Key           // This is a scratch slice
+	buttons []ebiten.GamepadButton // This is a scratch slice
+}
+
+// lastDevice selects the most recently used device.
+// The gamepadTick is a handler-specific gamepad activity tick.
+func (t *lastDeviceTracker) lastDevice(gamepadTick uint64) DeviceKind {
+	result := DeviceKind(0)
+	resultTick := uint64(0)
+	candidates := [...]struct {
+		kind DeviceKind
+		tick uint64
+	}{
+		{KeyboardDevice, t.keyboardTick},
+		{GamepadDevice, gamepadTick},
+		{MouseDevice, t.mouseTick},
+		{TouchDevice, t.touchTick},
+	}
+	for _, c := range candidates {
+		if c.tick > resultTick {
+			result = c.kind
+			resultTick = c.tick
+		}
+	}
+	return result
+}
+
+func (sys *System) updateLastDevice() {
+	t := &sys.lastDevice
+	t.tick++
+
+	t.keys = inpututil.AppendJustPressedKeys(t.keys[:0])
+	if len(t.keys) != 0 {
+		t.keyboardTick = t.tick
+	}
+
+	if sys.mouseEnabled {
+		if t.tick == 1 {
+			// The initial cursor position is not a mouse movement.
+			t.prevCursorPos = sys.cursorPos
+		}
+		mouseUsed := sys.cursorPos != t.prevCursorPos || sys.wheel != (Vec{})
+		t.prevCursorPos = sys.cursorPos
+		for b := ebiten.MouseButton0; b <= ebiten.MouseButtonMax && !mouseUsed; b++ {
+			mouseUsed = inpututil.IsMouseButtonJustPressed(b)
+		}
+		if mouseUsed {
+			t.mouseTick = t.tick
+		}
+	}
+
+	if sys.touchEnabled && len(sys.touchPoints) != 0 {
+		t.touchTick = t.tick
+	}
+
+	for i := range sys.gamepadInfo {
+		info := &sys.gamepadInfo[i]
+		if !info.connected {
+			continue
+		}
+		t.buttons = inpututil.AppendJustPressedGamepadButtons(info.device, t.buttons[:0])
+		if len(t.buttons) != 0 || info.axesMoved() {
+			info.lastActiveTick = t.tick
+		}
+	}
+}
+
+// axesMoved reports whether any of the axes was tilted during this frame.
+//
+// Some axes don't rest at 0 (Firefox XInput triggers rest at -1),
+// so only the changing values are treated as an activity.
+func (info *gamepadInfo) axesMoved() bool {
+	for i, v := range info.axisValues {
+		if math.Abs(v) > 0.5 && v != info.prevAxisValues[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// LastDevice reports the most recently used input device.
+// Only the handler's gamepad is taken into account.
+//
+// It can be used to select the matching prompts and glyphs
+// when a player switches between a keyboard and a gamepad.
+//
+// If none of the devices were used yet, DefaultInputMask-like logic is used:
+// GamepadDevice is returned if the gamepad is connected, KeyboardDevice otherwise.
+func (h *Handler) LastDevice() DeviceKind {
+	gamepadTick := uint64(0)
+	if h.GamepadConnected() {
+		gamepadTick = h.gamepadInfo().lastActiveTick
+	}
+	if d := h.sys.lastDevice.lastDevice(gamepadTick); d != 0 {
+		return d
+	}
+	if h.GamepadConnected() {
+		return GamepadDevice
+	}
+	return KeyboardDevice
+}
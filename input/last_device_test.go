@@ -0,0 +1,31 @@
+package input
+
+import "testing"
+
+func TestGamepadAxesMoved(t *testing.T) {
+	tests := []struct {
+		prev [8]float64
+		curr [8]float64
+		want bool
+	}{
+		{[8]float64{}, [8]float64{}, false},
+		// Firefox XInput triggers at rest.
+		{[8]float64{2: -1, 5: -1}, [8]float64{2: -1, 5: -1}, false},
+		// A trigger is being pressed.
+		{[8]float64{2: -1, 5: -1}, [8]float64{2: -0.2, 5: -1}, false},
+		{[8]float64{2: -0.2, 5: -1}, [8]float64{2: 0.7, 5: -1}, true},
+		// A small stick tilt is not enough.
+		{[8]float64{}, [8]float64{0: 0.3}, false},
+		{[8]float64{}, [8]float64{1: -0.8}, true},
+		// A stick is being held.
+		{[8]float64{0: 0.9}, [8]float64{0: 0.9}, false},
+		{[8]float64{0: 0.9}, [8]float64{0: 0.95}, true},
+	}
+
+	for _, test := range tests {
+		info := gamepadInfo{prevAxisValues: test.prev, axisValues: test.curr}
+		if have := info.axesMoved(); have != test.want {
+			t.Fatalf("axesMoved(prev=%v, curr=%v):\nhave: %v\nwant: %v", test.prev, test.curr, have, test.want)
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 type Vec = gmath.Vec
 
+type Rect = gmath.Rect
+
 func vecDistance(v, v2 Vec) float64 {
 	return v.DistanceTo(v2)
 }
@@ -29,3 +31,15 @@ func vecAngle(v Vec) float64 {
 func angleNormalized(radians float64) float64 {
 	return float64(gmath.Rad(radians).Normalized())
 }
+
+func vecAdd(v, v2 Vec) Vec {
+	return v.Add(v2)
+}
+
+func vecSub(v, v2 Vec) Vec {
+	return v.Sub(v2)
+}
+
+func vecMulf(v Vec, scalar float64) Vec {
+	return v.Mulf(scalar)
+}
@@ -2,7 +2,7 @@ package input
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/quasilyte/ge/gesignal"
 )
 
 // System is the main component of the input library.
@@ -22,7 +22,16 @@ import (
 // The system is usually not used directly after the input handlers are created.
 // Use input handlers to handle the user input.
 type System struct {
-	gamepadIDs  []ebiten.GamepadID
+	// EventGamepadConnected is emitted when a gamepad device is connected.
+	EventGamepadConnected gesignal.Event[GamepadEvent]
+
+	// EventGamepadDisconnected is emitted when a gamepad device is disconnected.
+	EventGamepadDisconnected gesignal.Event[GamepadEvent]
+
+	gamepadIDs     []ebiten.GamepadID
+	prevGamepadIDs []ebiten.GamepadID
+
+	// gamepadInfo is indexed by a player ID, it's a player gamepad slot.
 	gamepadInfo []gamepadInfo
 
 	pendingEvents       []simulatedEvent
@@ -30,18 +39,23 @@ type System struct {
 	simulatedEvents     []simulatedEvent
 	hasSimulatedActions bool
 
-	touchEnabled     bool
-	touchHasTap      bool
-	touchHasLongTap  bool
-	touchJustHadDrag bool
-	touchHasDrag     bool
-	touchDragging    bool
-	touchIDs         []ebiten.TouchID // This is a scratch slice, we don't support multi-touches yet
-	touchActiveID    ebiten.TouchID
-	touchTapPos      Vec
-	touchDragPos     Vec
-	touchStartPos    Vec
-	touchTime        float64
+	touchEnabled bool
+	touch        touchGestures
+	touchIDs     []ebiten.TouchID // This is a scratch slice
+	touchPoints  []touchPoint     // This is a scratch slice
+
+	contexts []*Handler
+
+	text      textInput
+	clipboard Clipboard
+
+	vibrator     GamepadVibrator
+	vibrationSeq uint32
+
+	lastDevice lastDeviceTracker
+
+	virtualControls []*VirtualControls
+	freeTouchPoints []touchPoint // This is a scratch slice
 
 	mouseEnabled bool
 	cursorPos    Vec
@@ -61,11 +75,14 @@ func (sys *System) Init(config SystemConfig) {
 	sys.mouseEnabled = config.DevicesEnabled&MouseDevice != 0
 
 	sys.gamepadIDs = make([]ebiten.GamepadID, 0, 8)
+	sys.prevGamepadIDs = make([]ebiten.GamepadID, 0, 8)
 	sys.gamepadInfo = make([]gamepadInfo, 8)
+	sys.initGamepadSlots()
 
 	if sys.touchEnabled {
 		sys.touchIDs = make([]ebiten.TouchID, 0, 8)
-		sys.touchActiveID = -1
+		sys.touchPoints = make([]touchPoint, 0, 8)
+		sys.touch.init()
 	}
 }
 
@@ -89,74 +106,40 @@ func (sys *System) UpdateWithDelta(delta float64) {
 	}
 
 	sys.gamepadIDs = ebiten.AppendGamepadIDs(sys.gamepadIDs[:0])
-	if len(sys.gamepadIDs) != 0 {
-		for i, id := range sys.gamepadIDs {
-			info := &sys.gamepadInfo[i]
-			info.axisCount = ebiten.GamepadAxisCount(id)
-			modelName := ebiten.GamepadName(id)
-			if info.modelName != modelName {
-				info.modelName = modelName
-				if ebiten.IsStandardGamepadLayoutAvailable(id) {
-					info.model = gamepadStandard
-				} else if isFirefox() {
-					info.model = guessFirefoxGamepadModel(int(id))
-				} else {
-					info.model = guessGamepadModel(modelName)
-				}
+	sys.syncGamepadSlots(sys.gamepadIDs, describeGamepad)
+	for i := range sys.gamepadInfo {
+		info := &sys.gamepadInfo[i]
+		if !info.connected {
+			continue
+		}
+		id := info.device
+		info.axisCount = ebiten.GamepadAxisCount(id)
+		modelName := ebiten.GamepadName(id)
+		if info.modelName != modelName {
+			info.modelName = modelName
+			info.style = guessGamepadStyle(modelName, info.sdlID)
+			if ebiten.IsStandardGamepadLayoutAvailable(id) {
+				info.model = gamepadStandard
+			} else if isFirefox() {
+				info.model = guessFirefoxGamepadModel(int(id))
+			} else {
+				info.model = guessGamepadModel(modelName)
 			}
-			sys.updateGamepadInfo(id, info)
 		}
+		sys.updateGamepadInfo(id, info)
 	}
 
 	if sys.touchEnabled {
-		sys.touchHasTap = false
-		sys.touchHasLongTap = false
-		sys.touchHasDrag = false
-		sys.touchJustHadDrag = false
-		// Track the touch gesture release.
-		// If it was a tap, set a flag.
-		if sys.touchActiveID != -1 && inpututil.IsTouchJustReleased(sys.touchActiveID) {
-			if !sys.touchDragging {
-				if sys.touchTime >= 0.5 {
-					sys.touchHasLongTap = true
-				} else {
-					sys.touchHasTap = true
-				}
-				sys.touchTapPos = sys.touchStartPos
-			}
-			sys.touchActiveID = -1
-			sys.touchDragging = false
-		}
-		// Check if this gesture entered a drag mode.
-		// Drag mode gestures will not trigger a tap when released.
-		// Drag events emit a pos delta relative to a start pos every frame.
-		if sys.touchActiveID != -1 {
-			x, y := ebiten.TouchPosition(sys.touchActiveID)
-			currentPos := Vec{X: float64(x), Y: float64(y)}
-			if sys.touchDragging {
-				sys.touchHasDrag = true
-				sys.touchDragPos = currentPos
-			} else {
-				sys.touchTime += delta
-				if vecDistance(sys.touchStartPos, currentPos) > 5 {
-					sys.touchDragging = true
-					sys.touchJustHadDrag = true
-					sys.touchHasDrag = true
-					sys.touchDragPos = currentPos
-				}
-			}
-		}
-		// Check if a new touch gesture is started.
-		if sys.touchActiveID == -1 {
-			sys.touchIDs = inpututil.AppendJustPressedTouchIDs(sys.touchIDs[:0])
-			for _, id := range sys.touchIDs {
-				x, y := ebiten.TouchPosition(id)
-				sys.touchStartPos = Vec{X: float64(x), Y: float64(y)}
-				sys.touchActiveID = id
-				sys.touchTime = 0
-				break
-			}
+		sys.touchIDs = ebiten.AppendTouchIDs(sys.touchIDs[:0])
+		sys.touchPoints = sys.touchPoints[:0]
+		for _, id := range sys.touchIDs {
+			x, y := ebiten.TouchPosition(id)
+			sys.touchPoints = append(sys.touchPoints, touchPoint{
+				id:  id,
+				pos: Vec{X: float64(x), Y: float64(y)},
+			})
 		}
+		sys.touch.update(delta, sys.updateVirtualControls(sys.touchPoints))
 	}
 
 	if sys.mouseEnabled {
@@ -168,6 +151,13 @@ func (sys *System) UpdateWithDelta(delta float64) {
 		x, y := ebiten.Wheel()
 		sys.wheel = Vec{X: x, Y: y}
 	}
+
+	if sys.text.active {
+		sys.text.update(delta, sys.clipboard)
+	}
+
+	sys.updateVibration(delta)
+	sys.updateLastDevice()
 }
 
 // Update reads the input state and updates the information
@@ -184,6 +174,10 @@ func (sys *System) Update() {
 	sys.UpdateWithDelta(1.0 / 60.0)
 }
 
+func (sys *System) gamepadIsConnected(playerID uint8) bool {
+	return sys.gamepadInfo[playerID].connected
+}
+
 func (sys *System) updateGamepadInfo(id ebiten.GamepadID, info *gamepadInfo) {
 	switch info.model {
 	case gamepadStandard:
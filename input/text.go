@@ -0,0 +1,327 @@
+package input
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// TextEditKind is a text editing command kind.
+type TextEditKind uint8
+
+const (
+	TextEditUnknown TextEditKind = iota
+
+	// TextEditBackspace deletes the char (or word, see TextEditEvent.Word) before the caret.
+	TextEditBackspace
+
+	// TextEditDelete deletes the char (or word, see TextEditEvent.Word) after the caret.
+	TextEditDelete
+
+	TextEditLeft
+	TextEditRight
+	TextEditHome
+	TextEditEnd
+
+	TextEditSelectAll
+	TextEditCopy
+	TextEditCut
+
+	// TextEditPaste carries the clipboard contents inside TextEditEvent.Text.
+	// It's only emitted if the system has a clipboard, see System.SetClipboard.
+	TextEditPaste
+
+	// TextEditSubmit is emitted when enter is pressed.
+	TextEditSubmit
+
+	// TextEditCancel is emitted when escape is pressed.
+	TextEditCancel
+)
+
+// TextEditEvent is a text editing command produced by the text input mode.
+type TextEditEvent struct {
+	Kind TextEditKind
+
+	// Shift reports whether the shift key was being held.
+	// For the caret movement commands, it means "extend the selection".
+	Shift bool
+
+	// Word reports whether the ctrl key was being held.
+	// For the caret movement and deletion commands, it means "operate on words".
+	Word bool
+
+	// Text is a pasted text for the TextEditPaste command.
+	Text string
+}
+
+// TextComposition is an IME composition state.
+// The composition text is not committed yet and is usually
+// rendered at the caret position with some special style.
+type TextComposition struct {
+	Text string
+
+	// SelectionStart and SelectionEnd are the IME selection bounds in bytes.
+	SelectionStart int
+	SelectionEnd   int
+}
+
+// Clipboard provides the clipboard access for the text input mode.
+//
+// Ebitengine doesn't have a clipboard API, so the game
+// needs to provide the platform-specific implementation.
+type Clipboard interface {
+	ReadText() string
+	WriteText(s string)
+}
+
+// Key repeat settings for the text editing keys, in seconds.
+const (
+	textKeyRepeatDelay    = 0.5
+	textKeyRepeatInterval = 0.05
+)
+
+type textEditKey struct {
+	key    ebiten.Key
+	kind   TextEditKind
+	ctrl   bool
+	repeat bool
+}
+
+var textEditKeys = [...]textEditKey{
+	{key: ebiten.KeyBackspace, kind: TextEditBackspace, repeat: true},
+	{key: ebiten.KeyDelete, kind: TextEditDelete, repeat: true},
+	{key: ebiten.KeyArrowLeft, kind: TextEditLeft, repeat: true},
+	{key: ebiten.KeyArrowRight, kind: TextEditRight, repeat: true},
+	{key: ebiten.KeyHome, kind: TextEditHome},
+	{key: ebiten.KeyEnd, kind: TextEditEnd},
+	{key: ebiten.KeyA, kind: TextEditSelectAll, ctrl: true},
+	{key: ebiten.KeyC, kind: TextEditCopy, ctrl: true},
+	{key: ebiten.KeyX, kind: TextEditCut, ctrl: true},
+	{key: ebiten.KeyV, kind: TextEditPaste, ctrl: true},
+	{key: ebiten.KeyEnter, kind: TextEditSubmit},
+	{key: ebiten.KeyNumpadEnter, kind: TextEditSubmit},
+	{key: ebiten.KeyEscape, kind: TextEditCancel},
+}
+
+// keyRepeat implements the typematic key behavior:
+// the first press is reported immediately, then the key is
+// reported after a delay and with a fixed interval after that.
+type keyRepeat struct {
+	pressed bool
+	held    float64
+	next    float64
+}
+
+func (r *keyRepeat) update(pressed bool, delta float64) bool {
+	if !pressed {
+		*r = keyRepeat{}
+		return false
+	}
+	if !r.pressed {
+		*r = keyRepeat{pressed: true, next: textKeyRepeatDelay}
+		return true
+	}
+	r.held += delta
+	if r.held < r.next {
+		return false
+	}
+	r.next += textKeyRepeatInterval
+	return true
+}
+
+type textInput struct {
+	active bool
+	pos    Vec
+
+	states chan textinput.State
+	end    func()
+
+	composition TextComposition
+	chars       []rune
+	events      []TextEditEvent
+
+	repeats [len(textEditKeys)]keyRepeat
+}
+
+func (t *textInput) start(pos Vec) {
+	if t.active && t.pos == pos {
+		return
+	}
+	// If the position is changed, a new session will be started
+	// with the updated IME candidate window location.
+	t.endSession()
+	t.active = true
+	t.pos = pos
+}
+
+func (t *textInput) stop() {
+	t.endSession()
+	t.active = false
+	t.chars = t.chars[:0]
+	t.events = t.events[:0]
+	t.repeats = [len(textEditKeys)]keyRepeat{}
+}
+
+func (t *textInput) endSession() {
+	if t.end != nil {
+		t.end()
+	}
+	t.states = nil
+	t.end = nil
+	t.composition = TextComposition{}
+}
+
+func (t *textInput) update(delta float64, clipboard Clipboard) {
+	t.chars = t.chars[:0]
+	t.events = t.events[:0]
+
+	imeComposing := false
+	for {
+		if t.states == nil {
+			t.states, t.end = textinput.Start(int(t.pos.X), int(t.pos.Y))
+			if t.states == nil {
+				// IME is not supported in this environment.
+				t.appendChars()
+				break
+			}
+		}
+		if t.readStates() {
+			imeComposing = true
+		}
+		if t.states != nil {
+			break
+		}
+		// The session was closed; start a new one.
+	}
+
+	ctrl := ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	for i, k := range textEditKeys {
+		pressed := ebiten.IsKeyPressed(k.key) && (!k.ctrl || ctrl)
+		var fire bool
+		if k.repeat {
+			fire = t.repeats[i].update(pressed, delta)
+		} else {
+			fire = pressed && inpututil.IsKeyJustPressed(k.key)
+		}
+		if !fire || imeComposing {
+			// The keys pressed during the IME composition belong to the IME.
+			continue
+		}
+		e := TextEditEvent{Kind: k.kind, Shift: shift, Word: ctrl && !k.ctrl}
+		if k.kind == TextEditPaste {
+			if clipboard == nil {
+				continue
+			}
+			e.Text = clipboard.ReadText()
+		}
+		t.events = append(t.events, e)
+	}
+}
+
+// readStates reads all pending IME states.
+//
+// It returns true if the IME composition was in progress during this frame.
+// The plain committed chars (without a composition) don't count.
+func (t *textInput) readStates() bool {
+	composing := t.composition.Text != ""
+	for {
+		select {
+		case state, ok := <-t.states:
+			if !ok {
+				t.states = nil
+				t.end = nil
+				t.composition = TextComposition{}
+				return composing
+			}
+			if state.Committed {
+				t.chars = append(t.chars, []rune(state.Text)...)
+				t.composition = TextComposition{}
+				continue
+			}
+			t.composition = TextComposition{
+				Text:           state.Text,
+				SelectionStart: state.CompositionSelectionStartInBytes,
+				SelectionEnd:   state.CompositionSelectionEndInBytes,
+			}
+			if state.Text != "" {
+				composing = true
+			}
+		default:
+			return composing
+		}
+	}
+}
+
+func (t *textInput) appendChars() {
+	start := len(t.chars)
+	t.chars = ebiten.AppendInputChars(t.chars)
+	// Filter out the control characters, they're reported as edit events.
+	filtered := t.chars[:start]
+	for _, r := range t.chars[start:] {
+		if r < 0x20 || r == 0x7f {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	t.chars = filtered
+}
+
+// SetClipboard assigns the clipboard implementation used by the text input mode.
+// Without a clipboard, the paste commands are not reported.
+func (sys *System) SetClipboard(c Clipboard) {
+	sys.clipboard = c
+}
+
+// StartTextInput enables the text input mode.
+//
+// While this mode is active, all keyboard-bound actions are suspended
+// for all handlers: the keyboard is used for typing.
+// Use TextInputChars and TextEditEvents to read the typed text and editing commands.
+//
+// The pos is a location for the IME candidate window, usually a caret position.
+// Calling this method with a new position moves the IME window.
+//
+// Note: the text input state is updated during the System.Update() call.
+func (h *Handler) StartTextInput(pos Vec) {
+	h.sys.text.start(pos)
+}
+
+// StopTextInput disables the text input mode.
+// The suspended keyboard actions are active again.
+func (h *Handler) StopTextInput() {
+	h.sys.text.stop()
+}
+
+// TextInputActive reports whether the text input mode is enabled.
+func (h *Handler) TextInputActive() bool {
+	return h.sys.text.active
+}
+
+// TextInputChars returns the runes typed during this frame.
+// The returned slice is only valid during the current frame and should not be modified.
+func (h *Handler) TextInputChars() []rune {
+	return h.sys.text.chars
+}
+
+// TextEditEvents returns the text editing commands issued during this frame.
+// The editing keys are repeated while being held.
+// The returned slice is only valid during the current frame and should not be modified.
+func (h *Handler) TextEditEvents() []TextEditEvent {
+	return h.sys.text.events
+}
+
+// TextComposition returns the current IME composition state.
+// The IME is only supported on some platforms (macOS and browsers);
+// the text is empty if there is no active composition.
+func (h *Handler) TextComposition() TextComposition {
+	return h.sys.text.composition
+}
+
+// SetClipboardText writes the text to the clipboard.
+// It's a no-op if the system has no clipboard, see System.SetClipboard.
+func (h *Handler) SetClipboardText(s string) {
+	if h.sys.clipboard != nil {
+		h.sys.clipboard.WriteText(s)
+	}
+}
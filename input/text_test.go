@@ -0,0 +1,91 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+)
+
+func TestKeyRepeat(t *testing.T) {
+	const delta = 0.125
+	var r keyRepeat
+	var fired []int
+	for i := 0; i < 10; i++ {
+		if r.update(true, delta) {
+			fired = append(fired, i)
+		}
+	}
+	// The first press fires immediately, then after the 0.5s delay
+	// the key is repeated every frame (the interval is less than delta).
+	want := []int{0, 4, 5, 6, 7, 8, 9}
+	if len(fired) != len(want) {
+		t.Fatalf("fired at %v, expected %v", fired, want)
+	}
+	for i := range want {
+		if fired[i] != want[i] {
+			t.Fatalf("fired at %v, expected %v", fired, want)
+		}
+	}
+
+	if r.update(false, delta) {
+		t.Fatal("released key should not fire")
+	}
+	if !r.update(true, delta) {
+		t.Fatal("a new press should fire immediately")
+	}
+	if r.update(true, delta) {
+		t.Fatal("the repeat delay is not respected")
+	}
+}
+
+func TestTextInputReadStates(t *testing.T) {
+	tests := []struct {
+		name        string
+		composition string
+		states      []textinput.State
+		chars       string
+		composing   bool
+	}{
+		{
+			name:   "committed chars",
+			states: []textinput.State{{Text: "a", Committed: true}, {Text: "b", Committed: true}},
+			chars:  "ab",
+		},
+		{
+			name:      "composition started",
+			states:    []textinput.State{{Text: "k"}},
+			composing: true,
+		},
+		{
+			name:        "composition committed",
+			composition: "ka",
+			states:      []textinput.State{{Text: "か", Committed: true}},
+			chars:       "か",
+			composing:   true,
+		},
+		{
+			name:        "composition erased",
+			composition: "k",
+			states:      []textinput.State{{Text: ""}},
+			composing:   true,
+		},
+		{
+			name: "no states",
+		},
+	}
+
+	for _, test := range tests {
+		states := make(chan textinput.State, len(test.states))
+		for _, s := range test.states {
+			states <- s
+		}
+		var ti textInput
+		ti.states = states
+		ti.composition.Text = test.composition
+		composing := ti.readStates()
+		if string(ti.chars) != test.chars || composing != test.composing {
+			t.Fatalf("%s:\nhave: %q composing=%v\nwant: %q composing=%v",
+				test.name, string(ti.chars), composing, test.chars, test.composing)
+		}
+	}
+}
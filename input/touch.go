@@ -0,0 +1,351 @@
+package input
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TouchInfo describes an active screen touch.
+type TouchInfo struct {
+	ID ebiten.TouchID
+
+	// Pos is a current touch location.
+	Pos Vec
+
+	// StartPos is a location where this touch was started.
+	StartPos Vec
+
+	// Duration is a touch lifetime in seconds.
+	Duration float64
+}
+
+// Gesture recognition thresholds.
+const (
+	touchDragThreshold   = 5.0
+	touchLongTapDuration = 0.5
+
+	touchDoubleTapInterval = 0.3
+	touchDoubleTapDistance = 30.0
+
+	touchSwipeMinDistance = 50.0
+	touchSwipeMaxDuration = 0.5
+
+	touchPinchThreshold  = 10.0
+	touchPanThreshold    = 10.0
+	touchRotateThreshold = 0.15
+)
+
+type touchPoint struct {
+	id  ebiten.TouchID
+	pos Vec
+}
+
+// touchGestures tracks all active touches and recognizes the gestures.
+//
+// The single-touch gestures (tap, long tap, drag, swipe, double tap)
+// are recognized for the primary touch: the first touch of the gesture.
+// If another touch is started while the primary touch is active,
+// the gesture becomes a multi-touch one (pinch, pan, rotate).
+type touchGestures struct {
+	touches []TouchInfo
+
+	time float64
+
+	// The primary touch state.
+	primaryID     ebiten.TouchID
+	multi         bool
+	dragging      bool
+	hasTap        bool
+	hasLongTap    bool
+	justHadDrag   bool
+	hasDrag       bool
+	hasDoubleTap  bool
+	tapPos        Vec
+	startPos      Vec
+	dragPos       Vec
+	prevDragPos   Vec
+	lastTapTime   float64
+	lastTapPos    Vec
+	hasLastTap    bool
+	swipe         touchCode
+	swipeStartPos Vec
+	swipePos      Vec
+
+	// The two-finger gesture state.
+	pairActive  bool
+	pairIDs     [2]ebiten.TouchID
+	startCenter Vec
+	center      Vec
+	prevCenter  Vec
+	startDist   float64
+	dist        float64
+	prevDist    float64
+	startAngle  float64
+	angle       float64
+	prevAngle   float64
+	pinching    bool
+	panning     bool
+	rotating    bool
+	justPinched bool
+	justPanned  bool
+	justRotated bool
+}
+
+func (g *touchGestures) init() {
+	g.touches = make([]TouchInfo, 0, 8)
+	g.primaryID = -1
+}
+
+func (g *touchGestures) update(delta float64, points []touchPoint) {
+	g.time += delta
+
+	g.hasTap = false
+	g.hasLongTap = false
+	g.hasDrag = false
+	g.justHadDrag = false
+	g.hasDoubleTap = false
+	g.swipe = touchUnknown
+	g.justPinched = false
+	g.justPanned = false
+	g.justRotated = false
+
+	// Handle the released touches.
+	live := g.touches[:0]
+	for _, t := range g.touches {
+		if touchPointIndex(points, t.ID) == -1 {
+			if t.ID == g.primaryID {
+				g.releasePrimary(t)
+			}
+			continue
+		}
+		live = append(live, t)
+	}
+	g.touches = live
+
+	// Update the active touches and register the new ones.
+	for _, p := range points {
+		i := g.touchIndex(p.id)
+		if i != -1 {
+			g.touches[i].Pos = p.pos
+			g.touches[i].Duration += delta
+			continue
+		}
+		g.touches = append(g.touches, TouchInfo{ID: p.id, Pos: p.pos, StartPos: p.pos})
+		if g.primaryID == -1 && len(g.touches) == 1 {
+			g.primaryID = p.id
+			g.startPos = p.pos
+			g.multi = false
+			g.dragging = false
+		}
+	}
+
+	if len(g.touches) > 1 {
+		g.multi = true
+	}
+	g.updatePrimary()
+	g.updatePair()
+}
+
+func (g *touchGestures) updatePrimary() {
+	if g.primaryID == -1 || g.multi {
+		return
+	}
+	t := g.touches[g.touchIndex(g.primaryID)]
+	// Check if this gesture entered a drag mode.
+	// Drag mode gestures will not trigger a tap when released.
+	if !g.dragging {
+		if vecDistance(g.startPos, t.Pos) <= touchDragThreshold {
+			return
+		}
+		g.dragging = true
+		g.justHadDrag = true
+		g.dragPos = g.startPos
+	}
+	g.hasDrag = true
+	g.prevDragPos = g.dragPos
+	g.dragPos = t.Pos
+}
+
+func (g *touchGestures) releasePrimary(t TouchInfo) {
+	g.primaryID = -1
+	if g.multi {
+		return
+	}
+
+	if !g.dragging {
+		if t.Duration >= touchLongTapDuration {
+			g.hasLongTap = true
+		} else {
+			g.hasTap = true
+			if g.hasLastTap && g.time-g.lastTapTime <= touchDoubleTapInterval && vecDistance(g.lastTapPos, g.startPos) <= touchDoubleTapDistance {
+				g.hasDoubleTap = true
+				// A third tap should not be counted as another double tap.
+				g.hasLastTap = false
+			} else {
+				g.hasLastTap = true
+				g.lastTapTime = g.time
+				g.lastTapPos = g.startPos
+			}
+		}
+		g.tapPos = g.startPos
+		return
+	}
+
+	g.dragging = false
+	offset := vecSub(t.Pos, g.startPos)
+	if t.Duration > touchSwipeMaxDuration || vecLen(offset) < touchSwipeMinDistance {
+		return
+	}
+	g.swipeStartPos = g.startPos
+	g.swipePos = t.Pos
+	if math.Abs(offset.X) >= math.Abs(offset.Y) {
+		if offset.X > 0 {
+			g.swipe = touchSwipeRight
+		} else {
+			g.swipe = touchSwipeLeft
+		}
+	} else {
+		if offset.Y > 0 {
+			g.swipe = touchSwipeDown
+		} else {
+			g.swipe = touchSwipeUp
+		}
+	}
+}
+
+func (g *touchGestures) updatePair() {
+	if len(g.touches) < 2 {
+		g.pairActive = false
+		g.pinching = false
+		g.panning = false
+		g.rotating = false
+		return
+	}
+
+	a := g.touches[0]
+	b := g.touches[1]
+	center := vecMulf(vecAdd(a.Pos, b.Pos), 0.5)
+	dist := vecDistance(a.Pos, b.Pos)
+	angle := vecAngle(vecSub(b.Pos, a.Pos))
+
+	if !g.pairActive || g.pairIDs != [2]ebiten.TouchID{a.ID, b.ID} {
+		// A new two-finger gesture is started.
+		g.pairActive = true
+		g.pairIDs = [2]ebiten.TouchID{a.ID, b.ID}
+		g.pinching = false
+		g.panning = false
+		g.rotating = false
+		g.startCenter = center
+		g.startDist = dist
+		g.startAngle = angle
+		g.center = center
+		g.dist = dist
+		g.angle = angle
+	}
+
+	g.prevCenter = g.center
+	g.prevDist = g.dist
+	g.prevAngle = g.angle
+	g.center = center
+	g.dist = dist
+	g.angle = angle
+
+	if !g.pinching && math.Abs(g.dist-g.startDist) > touchPinchThreshold {
+		g.pinching = true
+		g.justPinched = true
+	}
+	if !g.panning && vecDistance(g.center, g.startCenter) > touchPanThreshold {
+		g.panning = true
+		g.justPanned = true
+	}
+	if !g.rotating && math.Abs(angleDelta(g.startAngle, g.angle)) > touchRotateThreshold {
+		g.rotating = true
+		g.justRotated = true
+	}
+}
+
+func (g *touchGestures) touchIndex(id ebiten.TouchID) int {
+	for i, t := range g.touches {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *touchGestures) isJustPressed(code touchCode) bool {
+	switch code {
+	case touchPinch:
+		return g.justPinched
+	case touchPan:
+		return g.justPanned
+	case touchRotate:
+		return g.justRotated
+	default:
+		return g.isPressed(code)
+	}
+}
+
+func (g *touchGestures) isPressed(code touchCode) bool {
+	switch code {
+	case touchTap:
+		return g.hasTap
+	case touchLongTap:
+		return g.hasLongTap
+	case touchDoubleTap:
+		return g.hasDoubleTap
+	case touchSwipe:
+		return g.swipe != touchUnknown
+	case touchSwipeUp, touchSwipeRight, touchSwipeDown, touchSwipeLeft:
+		return g.swipe == code
+	case touchPinch:
+		return g.pinching
+	case touchPan:
+		return g.panning
+	case touchRotate:
+		return g.rotating
+	default:
+		return false
+	}
+}
+
+func (g *touchGestures) fillEventInfo(info *EventInfo, code touchCode) {
+	switch code {
+	case touchTap, touchLongTap, touchDoubleTap:
+		info.Pos = g.tapPos
+	case touchSwipe, touchSwipeUp, touchSwipeRight, touchSwipeDown, touchSwipeLeft:
+		info.Pos = g.swipePos
+		info.StartPos = g.swipeStartPos
+		info.Delta = vecSub(g.swipePos, g.swipeStartPos)
+	case touchPinch, touchPan, touchRotate:
+		info.Pos = g.center
+		info.StartPos = g.startCenter
+		info.Delta = vecSub(g.center, g.prevCenter)
+		info.Scale = 1
+		if g.prevDist != 0 {
+			info.Scale = g.dist / g.prevDist
+		}
+		info.Rotation = angleDelta(g.prevAngle, g.angle)
+	}
+}
+
+func touchPointIndex(points []touchPoint, id ebiten.TouchID) int {
+	for i, p := range points {
+		if p.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// angleDelta returns the shortest signed rotation from one angle to another.
+func angleDelta(from, to float64) float64 {
+	d := math.Mod(to-from, 2*math.Pi)
+	if d > math.Pi {
+		d -= 2 * math.Pi
+	} else if d < -math.Pi {
+		d += 2 * math.Pi
+	}
+	return d
+}
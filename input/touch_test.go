@@ -0,0 +1,112 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type touchFrame []touchPoint
+
+func touchAt(id ebiten.TouchID, x, y float64) touchPoint {
+	return touchPoint{id: id, pos: Vec{X: x, Y: y}}
+}
+
+func runTouchFrames(g *touchGestures, frames []touchFrame, check func(frame int)) {
+	for i, points := range frames {
+		g.update(1.0/60.0, points)
+		check(i)
+	}
+}
+
+func TestTouchTapGestures(t *testing.T) {
+	var g touchGestures
+	g.init()
+
+	frames := []touchFrame{
+		{touchAt(0, 10, 10)},
+		{},
+		{touchAt(1, 12, 11)},
+		{},
+	}
+	var taps, doubleTaps int
+	runTouchFrames(&g, frames, func(frame int) {
+		if g.isPressed(touchTap) {
+			taps++
+		}
+		if g.isPressed(touchDoubleTap) {
+			doubleTaps++
+			if frame != 3 {
+				t.Fatalf("double tap is reported at frame %d", frame)
+			}
+		}
+	})
+	if taps != 2 || doubleTaps != 1 {
+		t.Fatalf("have %d taps and %d double taps, want 2 and 1", taps, doubleTaps)
+	}
+}
+
+func TestTouchSwipeGesture(t *testing.T) {
+	var g touchGestures
+	g.init()
+
+	frames := []touchFrame{
+		{touchAt(0, 100, 100)},
+		{touchAt(0, 80, 105)},
+		{touchAt(0, 30, 110)},
+		{},
+	}
+	runTouchFrames(&g, frames, func(frame int) {
+		swiped := g.isPressed(touchSwipe)
+		if swiped != (frame == 3) {
+			t.Fatalf("frame %d: unexpected swipe state %v", frame, swiped)
+		}
+		if frame == 1 && !g.justHadDrag {
+			t.Fatalf("frame %d: drag is not started", frame)
+		}
+	})
+	if !g.isPressed(touchSwipeLeft) || g.isPressed(touchSwipeDown) {
+		t.Fatalf("expected a left swipe")
+	}
+	var info EventInfo
+	g.fillEventInfo(&info, touchSwipeLeft)
+	if info.Delta != (Vec{X: -70, Y: 10}) {
+		t.Fatalf("unexpected swipe delta: %v", info.Delta)
+	}
+}
+
+func TestTouchPinchGesture(t *testing.T) {
+	var g touchGestures
+	g.init()
+
+	frames := []touchFrame{
+		{touchAt(0, 100, 100)},
+		{touchAt(0, 100, 100), touchAt(1, 200, 100)},
+		{touchAt(0, 95, 100), touchAt(1, 205, 100)},
+		{touchAt(0, 90, 100), touchAt(1, 210, 100)},
+		{touchAt(0, 80, 100), touchAt(1, 220, 100)},
+		{touchAt(0, 80, 100)},
+		{},
+	}
+	runTouchFrames(&g, frames, func(frame int) {
+		if g.isPressed(touchTap) || g.isPressed(touchDrag) {
+			t.Fatalf("frame %d: a multi-touch gesture triggered a single-touch event", frame)
+		}
+		if g.isJustPressed(touchPinch) != (frame == 3) {
+			t.Fatalf("frame %d: unexpected pinch activation state", frame)
+		}
+		if g.isPressed(touchPinch) != (frame == 3 || frame == 4) {
+			t.Fatalf("frame %d: unexpected pinch state", frame)
+		}
+		if g.isPressed(touchPan) || g.isPressed(touchRotate) {
+			t.Fatalf("frame %d: unexpected pan or rotate", frame)
+		}
+		if frame == 4 {
+			var info EventInfo
+			g.fillEventInfo(&info, touchPinch)
+			if info.Scale != 140.0/120.0 || info.Pos != (Vec{X: 150, Y: 100}) {
+				t.Fatalf("unexpected pinch info: scale=%v pos=%v", info.Scale, info.Pos)
+			}
+		}
+	})
+}
@@ -0,0 +1,263 @@
+package input
+
+import (
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Vibration describes a gamepad rumble effect.
+type Vibration struct {
+	// StrongMagnitude is the low-frequency rumble motor intensity, in [0, 1] range.
+	StrongMagnitude float64
+
+	// WeakMagnitude is the high-frequency rumble motor intensity, in [0, 1] range.
+	WeakMagnitude float64
+
+	// Duration is the effect duration in seconds.
+	Duration float64
+
+	// Priority is used to resolve the overlapping effects.
+	// Only the active effects with the highest priority are played,
+	// the lower priority effects are muted (but their time still passes).
+	// The effects with equal priorities are mixed by using the max magnitude.
+	Priority int
+
+	// Queued effects are started after all effects that
+	// are already playing (or queued) for this gamepad are finished.
+	Queued bool
+}
+
+// VibrationID identifies the played vibration effect.
+// It can be used to cancel the effect.
+type VibrationID uint32
+
+// GamepadVibrator is an interface that sends the vibration commands to the devices.
+//
+// The default vibrator uses ebiten.VibrateGamepad, so it only works
+// where Ebitengine supports it (browsers and Nintendo Switch at the moment of writing);
+// it's a no-op on other platforms.
+//
+// Use System.SetVibrator to replace it, for example, with a VibrationRecorder in tests.
+type GamepadVibrator interface {
+	// VibrateGamepad starts the vibration with the given magnitudes and duration (in seconds).
+	// The new vibration replaces the previous one.
+	// Zero magnitudes mean "stop the vibration".
+	VibrateGamepad(playerID uint8, strong, weak, duration float64)
+}
+
+// VibrationRecorder is a test double that records the vibration commands.
+type VibrationRecorder struct {
+	Records []VibrationRecord
+}
+
+// VibrationRecord is a vibration command recorded by the VibrationRecorder.
+type VibrationRecord struct {
+	PlayerID uint8
+	Strong   float64
+	Weak     float64
+	Duration float64
+}
+
+func (r *VibrationRecorder) VibrateGamepad(playerID uint8, strong, weak, duration float64) {
+	r.Records = append(r.Records, VibrationRecord{
+		PlayerID: playerID,
+		Strong:   strong,
+		Weak:     weak,
+		Duration: duration,
+	})
+}
+
+type ebitenVibrator struct {
+	sys *System
+}
+
+func (v ebitenVibrator) VibrateGamepad(playerID uint8, strong, weak, duration float64) {
+	id, ok := v.sys.PlayerGamepad(playerID)
+	if !ok {
+		return
+	}
+	ebiten.VibrateGamepad(id, &ebiten.VibrateGamepadOptions{
+		Duration:        time.Duration(duration * float64(time.Second)),
+		StrongMagnitude: strong,
+		WeakMagnitude:   weak,
+	})
+}
+
+// SetVibrator replaces the gamepad vibration backend.
+func (sys *System) SetVibrator(v GamepadVibrator) {
+	sys.vibrator = v
+}
+
+// Vibrate plays the vibration effect on the handler's gamepad.
+// The strong and weak values are the rumble motors magnitudes in [0, 1] range.
+// The duration is specified in seconds.
+//
+// It's a shorthand for PlayVibration with a default priority.
+func (h *Handler) Vibrate(strong, weak, duration float64) VibrationID {
+	return h.PlayVibration(Vibration{
+		StrongMagnitude: strong,
+		WeakMagnitude:   weak,
+		Duration:        duration,
+	})
+}
+
+// PlayVibration adds the vibration effect to the handler's gamepad effects list.
+//
+// The effects can overlap, see Vibration.Priority and Vibration.Queued
+// to learn how they're combined.
+func (h *Handler) PlayVibration(v Vibration) VibrationID {
+	h.sys.vibrationSeq++
+	id := VibrationID(h.sys.vibrationSeq)
+	h.gamepadInfo().rumble.add(id, v)
+	h.sys.applyVibration(h.id, 0)
+	return id
+}
+
+// CancelVibration stops the effect playback.
+// It's a no-op if the effect is already finished.
+func (h *Handler) CancelVibration(id VibrationID) {
+	if h.gamepadInfo().rumble.cancel(id) {
+		h.sys.applyVibration(h.id, 0)
+	}
+}
+
+// StopVibration cancels all gamepad vibration effects.
+func (h *Handler) StopVibration() {
+	h.gamepadInfo().rumble.clear()
+	h.sys.applyVibration(h.id, 0)
+}
+
+func (sys *System) updateVibration(delta float64) {
+	for i := range sys.gamepadInfo {
+		if sys.gamepadInfo[i].rumble.isIdle() {
+			continue
+		}
+		sys.applyVibration(uint8(i), delta)
+	}
+}
+
+func (sys *System) applyVibration(playerID uint8, delta float64) {
+	strong, weak, duration, send := sys.gamepadInfo[playerID].rumble.update(delta)
+	if !send {
+		return
+	}
+	v := sys.vibrator
+	if v == nil {
+		v = ebitenVibrator{sys: sys}
+	}
+	v.VibrateGamepad(playerID, strong, weak, duration)
+}
+
+type vibrationEffect struct {
+	id VibrationID
+	Vibration
+
+	// delay is a time left before the queued effect is started.
+	delay   float64
+	elapsed float64
+}
+
+// rumbleState mixes the gamepad vibration effects.
+type rumbleState struct {
+	effects []vibrationEffect
+
+	// The last command sent to the vibrator.
+	strong   float64
+	weak     float64
+	sentLeft float64
+}
+
+func (r *rumbleState) isIdle() bool {
+	return len(r.effects) == 0 && r.strong == 0 && r.weak == 0
+}
+
+func (r *rumbleState) add(id VibrationID, v Vibration) {
+	e := vibrationEffect{id: id, Vibration: v}
+	if v.Queued {
+		for _, other := range r.effects {
+			e.delay = math.Max(e.delay, other.delay+other.Duration-other.elapsed)
+		}
+	}
+	r.effects = append(r.effects, e)
+}
+
+func (r *rumbleState) cancel(id VibrationID) bool {
+	for i, e := range r.effects {
+		if e.id == id {
+			r.effects = append(r.effects[:i], r.effects[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rumbleState) clear() {
+	r.effects = r.effects[:0]
+}
+
+// update advances the effects time and computes the resulting vibration.
+// The last result reports whether the vibration command needs to be sent.
+func (r *rumbleState) update(delta float64) (strong, weak, duration float64, send bool) {
+	live := r.effects[:0]
+	for _, e := range r.effects {
+		if e.delay > 0 {
+			e.delay -= delta
+			if e.delay < 0 {
+				e.elapsed = -e.delay
+				e.delay = 0
+			}
+		} else {
+			e.elapsed += delta
+		}
+		if e.delay == 0 && e.elapsed >= e.Duration {
+			continue
+		}
+		live = append(live, e)
+	}
+	r.effects = live
+
+	maxPriority := math.MinInt
+	for _, e := range r.effects {
+		if e.delay == 0 && e.Priority > maxPriority {
+			maxPriority = e.Priority
+		}
+	}
+
+	// duration is a time until the next effects list change:
+	// one of the effects is finished or a queued effect is started.
+	duration = math.Inf(1)
+	for _, e := range r.effects {
+		if e.delay > 0 {
+			duration = math.Min(duration, e.delay)
+			continue
+		}
+		duration = math.Min(duration, e.Duration-e.elapsed)
+		if e.Priority == maxPriority {
+			strong = math.Max(strong, e.StrongMagnitude)
+			weak = math.Max(weak, e.WeakMagnitude)
+		}
+	}
+
+	r.sentLeft -= delta
+	silent := strong == 0 && weak == 0
+	switch {
+	case strong != r.strong || weak != r.weak:
+		send = true
+	case !silent && r.sentLeft <= 0:
+		// The previous command is expired, but the vibration should go on.
+		send = true
+	}
+	if !send {
+		return strong, weak, duration, false
+	}
+
+	if silent {
+		duration = 0
+	}
+	r.strong = strong
+	r.weak = weak
+	r.sentLeft = duration
+	return strong, weak, duration, true
+}
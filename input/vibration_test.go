@@ -0,0 +1,75 @@
+package input
+
+import (
+	"testing"
+)
+
+func TestVibration(t *testing.T) {
+	var recorder VibrationRecorder
+	sys := System{gamepadInfo: make([]gamepadInfo, 8)}
+	sys.SetVibrator(&recorder)
+	h := sys.NewHandler(1, Keymap{})
+
+	type step struct {
+		delta float64
+		run   func()
+		want  []VibrationRecord
+	}
+
+	var explosion VibrationID
+	steps := []step{
+		{
+			run:  func() { h.Vibrate(0.5, 0.25, 1) },
+			want: []VibrationRecord{{PlayerID: 1, Strong: 0.5, Weak: 0.25, Duration: 1}},
+		},
+		{
+			// Nothing is changed.
+			delta: 0.25,
+		},
+		{
+			// A higher priority effect overrides the active one.
+			run: func() {
+				explosion = h.PlayVibration(Vibration{StrongMagnitude: 1, Duration: 0.25, Priority: 1})
+			},
+			want: []VibrationRecord{{PlayerID: 1, Strong: 1, Duration: 0.25}},
+		},
+		{
+			// The effect is canceled, the first effect is resumed.
+			run:  func() { h.CancelVibration(explosion) },
+			want: []VibrationRecord{{PlayerID: 1, Strong: 0.5, Weak: 0.25, Duration: 0.75}},
+		},
+		{
+			// The queued effect starts after the first one.
+			run: func() { h.PlayVibration(Vibration{WeakMagnitude: 1, Duration: 0.5, Queued: true}) },
+		},
+		{
+			delta: 0.75,
+			want:  []VibrationRecord{{PlayerID: 1, Weak: 1, Duration: 0.5}},
+		},
+		{
+			delta: 0.5,
+			want:  []VibrationRecord{{PlayerID: 1}},
+		},
+		{
+			delta: 0.5,
+		},
+	}
+
+	for i, s := range steps {
+		recorder.Records = recorder.Records[:0]
+		if s.run != nil {
+			s.run()
+		}
+		if s.delta != 0 {
+			sys.updateVibration(s.delta)
+		}
+		if len(recorder.Records) != len(s.want) {
+			t.Fatalf("step[%d]: have %v, want %v", i, recorder.Records, s.want)
+		}
+		for j := range s.want {
+			if recorder.Records[j] != s.want[j] {
+				t.Fatalf("step[%d]: have %v, want %v", i, recorder.Records, s.want)
+			}
+		}
+	}
+}
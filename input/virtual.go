@@ -0,0 +1,376 @@
+package input
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// VirtualControls is a set of on-screen touch controls bound to a player.
+//
+// The controls consume the touches that start inside their areas
+// and emulate the player gamepad state: sticks move the gamepad stick axes,
+// buttons and d-pads press the gamepad buttons.
+// This way, the same keymap works for both real and virtual gamepads.
+//
+// The consumed touches are not reported as touch gestures (like taps and drags).
+//
+// Use System.NewVirtualControls to create the controls.
+type VirtualControls struct {
+	playerID uint8
+
+	// Disabled controls release all their touches and don't consume the new ones.
+	Disabled bool
+
+	Sticks  []*VirtualStick
+	DPads   []*VirtualDPad
+	Buttons []*VirtualButton
+}
+
+// VirtualStick is an on-screen analog stick.
+type VirtualStick struct {
+	// Pos is a stick center.
+	// For the floating sticks, it's a resting position used when the stick is not active.
+	Pos Vec
+
+	// Radius is a max knob offset from the stick center.
+	Radius float64
+
+	// Floating sticks are centered at the touch start location.
+	// A floating stick can be activated by a touch anywhere inside its Area.
+	Floating bool
+	Area     Rect
+
+	// RightStick selects the emulated gamepad stick.
+	// By default, the left stick is used.
+	RightStick bool
+
+	touchID ebiten.TouchID
+	active  bool
+	center  Vec
+	value   Vec
+}
+
+// IsActive reports whether the stick is being touched.
+func (s *VirtualStick) IsActive() bool { return s.active }
+
+// Center returns the current stick center.
+// For the floating sticks it can be different from the Pos.
+func (s *VirtualStick) Center() Vec {
+	if s.active {
+		return s.center
+	}
+	return s.Pos
+}
+
+// Value returns the stick value; every axis is in [-1, 1] range.
+func (s *VirtualStick) Value() Vec { return s.value }
+
+// KnobPos returns the current stick knob location.
+func (s *VirtualStick) KnobPos() Vec {
+	return vecAdd(s.Center(), vecMulf(s.value, s.Radius))
+}
+
+func (s *VirtualStick) contains(pos Vec) bool {
+	if s.Floating {
+		return s.Area.Contains(pos)
+	}
+	return vecDistance(s.Pos, pos) <= s.Radius
+}
+
+// VirtualDPad is an on-screen directional pad.
+// It emulates the gamepad d-pad buttons (like KeyGamepadUp).
+// Two buttons can be pressed at once for the diagonal directions.
+type VirtualDPad struct {
+	Pos    Vec
+	Radius float64
+
+	touchID   ebiten.TouchID
+	active    bool
+	direction Vec
+}
+
+// IsActive reports whether the d-pad is being touched.
+func (d *VirtualDPad) IsActive() bool { return d.active }
+
+// Direction returns the pressed direction; every axis is -1, 0 or 1.
+func (d *VirtualDPad) Direction() Vec { return d.direction }
+
+// VirtualButton is an on-screen button that emulates a gamepad button.
+type VirtualButton struct {
+	Pos    Vec
+	Radius float64
+
+	// Key is a gamepad button key to emulate, like KeyGamepadA.
+	Key Key
+
+	touchID ebiten.TouchID
+	active  bool
+}
+
+// IsPressed reports whether the button is being touched.
+func (b *VirtualButton) IsPressed() bool { return b.active }
+
+// NewVirtualControls creates an empty virtual controls set for the player.
+// Use VirtualControls methods to add the sticks and buttons.
+//
+// The player ID has the same meaning as in NewHandler.
+func (sys *System) NewVirtualControls(playerID uint8) *VirtualControls {
+	c := &VirtualControls{playerID: playerID}
+	sys.virtualControls = append(sys.virtualControls, c)
+	return c
+}
+
+// AddStick adds the stick to the set.
+// The returned pointer can be used to check the stick state.
+func (c *VirtualControls) AddStick(s VirtualStick) *VirtualStick {
+	p := &s
+	c.Sticks = append(c.Sticks, p)
+	return p
+}
+
+// AddDPad adds the d-pad to the set.
+// The returned pointer can be used to check the d-pad state.
+func (c *VirtualControls) AddDPad(d VirtualDPad) *VirtualDPad {
+	p := &d
+	c.DPads = append(c.DPads, p)
+	return p
+}
+
+// AddButton adds the button to the set.
+// The returned pointer can be used to check the button state.
+func (c *VirtualControls) AddButton(b VirtualButton) *VirtualButton {
+	if b.Key.kind != keyGamepad {
+		panic("virtual button key should be a gamepad button key")
+	}
+	p := &b
+	c.Buttons = append(c.Buttons, p)
+	return p
+}
+
+func (c *VirtualControls) release() {
+	for _, s := range c.Sticks {
+		s.active = false
+		s.value = Vec{}
+	}
+	for _, d := range c.DPads {
+		d.active = false
+		d.direction = Vec{}
+	}
+	for _, b := range c.Buttons {
+		b.active = false
+	}
+}
+
+// claim tries to bind a new touch to one of the controls.
+func (c *VirtualControls) claim(p touchPoint) bool {
+	if c.Disabled {
+		return false
+	}
+	for _, b := range c.Buttons {
+		if !b.active && vecDistance(b.Pos, p.pos) <= b.Radius {
+			b.active = true
+			b.touchID = p.id
+			return true
+		}
+	}
+	for _, d := range c.DPads {
+		if !d.active && vecDistance(d.Pos, p.pos) <= d.Radius {
+			d.active = true
+			d.touchID = p.id
+			return true
+		}
+	}
+	for _, s := range c.Sticks {
+		if !s.active && s.contains(p.pos) {
+			s.active = true
+			s.touchID = p.id
+			s.center = s.Pos
+			if s.Floating {
+				s.center = p.pos
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// owns reports whether the touch is bound to one of the controls.
+func (c *VirtualControls) owns(id ebiten.TouchID) bool {
+	for _, b := range c.Buttons {
+		if b.active && b.touchID == id {
+			return true
+		}
+	}
+	for _, d := range c.DPads {
+		if d.active && d.touchID == id {
+			return true
+		}
+	}
+	for _, s := range c.Sticks {
+		if s.active && s.touchID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *VirtualControls) update(points []touchPoint) {
+	if c.Disabled {
+		c.release()
+		return
+	}
+
+	for _, b := range c.Buttons {
+		if b.active && touchPointIndex(points, b.touchID) == -1 {
+			b.active = false
+		}
+	}
+
+	for _, d := range c.DPads {
+		if !d.active {
+			continue
+		}
+		i := touchPointIndex(points, d.touchID)
+		if i == -1 {
+			d.active = false
+			d.direction = Vec{}
+			continue
+		}
+		d.direction = dpadDirection(vecSub(points[i].pos, d.Pos), d.Radius)
+	}
+
+	for _, s := range c.Sticks {
+		if !s.active {
+			continue
+		}
+		i := touchPointIndex(points, s.touchID)
+		if i == -1 {
+			s.active = false
+			s.value = Vec{}
+			continue
+		}
+		offset := vecSub(points[i].pos, s.center)
+		if l := vecLen(offset); l > s.Radius {
+			offset = vecMulf(offset, s.Radius/l)
+		}
+		if s.Radius != 0 {
+			s.value = vecMulf(offset, 1/s.Radius)
+		}
+	}
+}
+
+func (c *VirtualControls) apply(info *gamepadInfo) {
+	for _, b := range c.Buttons {
+		if b.active {
+			info.virtualButtons |= 1 << b.Key.code
+		}
+	}
+	for _, d := range c.DPads {
+		if d.direction.Y < 0 {
+			info.virtualButtons |= 1 << ebiten.StandardGamepadButtonLeftTop
+		}
+		if d.direction.X > 0 {
+			info.virtualButtons |= 1 << ebiten.StandardGamepadButtonLeftRight
+		}
+		if d.direction.Y > 0 {
+			info.virtualButtons |= 1 << ebiten.StandardGamepadButtonLeftBottom
+		}
+		if d.direction.X < 0 {
+			info.virtualButtons |= 1 << ebiten.StandardGamepadButtonLeftLeft
+		}
+	}
+	for _, s := range c.Sticks {
+		if !s.active {
+			continue
+		}
+		axis1, axis2 := virtualStickAxes(info.model, s.RightStick)
+		info.axisValues[axis1] = s.value.X
+		info.axisValues[axis2] = s.value.Y
+	}
+}
+
+func virtualStickAxes(model gamepadModel, right bool) (int, int) {
+	if model == gamepadFirefoxXinput {
+		if right {
+			return 3, 4
+		}
+		return 0, 1
+	}
+	if right {
+		return int(ebiten.StandardGamepadAxisRightStickHorizontal), int(ebiten.StandardGamepadAxisRightStickVertical)
+	}
+	return int(ebiten.StandardGamepadAxisLeftStickHorizontal), int(ebiten.StandardGamepadAxisLeftStickVertical)
+}
+
+// dpadDirection converts a touch offset into an 8-way direction.
+func dpadDirection(offset Vec, radius float64) Vec {
+	l := vecLen(offset)
+	if l < radius*0.2 {
+		return Vec{}
+	}
+	// sin(22.5°): the diagonal sectors are 45 degrees wide.
+	const threshold = 0.38
+	var dir Vec
+	if x := offset.X / l; math.Abs(x) > threshold {
+		dir.X = math.Copysign(1, x)
+	}
+	if y := offset.Y / l; math.Abs(y) > threshold {
+		dir.Y = math.Copysign(1, y)
+	}
+	return dir
+}
+
+// updateVirtualControls processes the virtual controls input and
+// returns the touches that were not consumed by them.
+func (sys *System) updateVirtualControls(points []touchPoint) []touchPoint {
+	for _, c := range sys.virtualControls {
+		info := &sys.gamepadInfo[c.playerID]
+		info.prevVirtualButtons = info.virtualButtons
+		info.virtualButtons = 0
+		if !sys.gamepadIsConnected(c.playerID) || (info.model != gamepadStandard && info.model != gamepadFirefoxXinput) {
+			// The axes are not updated from the real device.
+			info.prevAxisValues = info.axisValues
+			info.axisValues = [len(info.axisValues)]float64{}
+		}
+	}
+
+	if len(sys.virtualControls) == 0 {
+		return points
+	}
+
+	for _, p := range points {
+		if sys.touch.touchIndex(p.id) != -1 || sys.virtualTouchIsConsumed(p.id) {
+			continue
+		}
+		// This is a new touch, the controls can claim it.
+		for _, c := range sys.virtualControls {
+			if c.claim(p) {
+				break
+			}
+		}
+	}
+
+	free := sys.freeTouchPoints[:0]
+	for _, c := range sys.virtualControls {
+		c.update(points)
+		c.apply(&sys.gamepadInfo[c.playerID])
+	}
+	for _, p := range points {
+		if !sys.virtualTouchIsConsumed(p.id) {
+			free = append(free, p)
+		}
+	}
+	sys.freeTouchPoints = free
+
+	return free
+}
+
+func (sys *System) virtualTouchIsConsumed(id ebiten.TouchID) bool {
+	for _, c := range sys.virtualControls {
+		if c.owns(id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestDPadDirection(t *testing.T) {
+	tests := []struct {
+		offset Vec
+		want   Vec
+	}{
+		{Vec{X: 1, Y: 1}, Vec{}},
+		{Vec{X: 40, Y: 0}, Vec{X: 1}},
+		{Vec{X: 0, Y: -40}, Vec{Y: -1}},
+		{Vec{X: -30, Y: 30}, Vec{X: -1, Y: 1}},
+		{Vec{X: 40, Y: 10}, Vec{X: 1}},
+	}
+	for _, test := range tests {
+		have := dpadDirection(test.offset, 50)
+		if have != test.want {
+			t.Fatalf("dpadDirection(%v):\nhave: %v\nwant: %v", test.offset, have, test.want)
+		}
+	}
+}
+
+func TestVirtualControls(t *testing.T) {
+	c := &VirtualControls{}
+	stick := c.AddStick(VirtualStick{
+		Floating: true,
+		Area:     Rect{Max: Vec{X: 100, Y: 100}},
+		Radius:   20,
+	})
+	button := c.AddButton(VirtualButton{
+		Pos:    Vec{X: 200, Y: 50},
+		Radius: 10,
+		Key:    KeyGamepadA,
+	})
+
+	for _, p := range []touchPoint{touchAt(0, 50, 50), touchAt(1, 205, 50), touchAt(2, 150, 50)} {
+		c.claim(p)
+	}
+	if !stick.IsActive() || !button.IsPressed() {
+		t.Fatal("the controls did not claim the touches")
+	}
+	if c.owns(2) {
+		t.Fatal("a touch outside of the controls was claimed")
+	}
+
+	c.update([]touchPoint{touchAt(0, 90, 50), touchAt(1, 205, 50)})
+	if stick.Value() != (Vec{X: 1}) || stick.KnobPos() != (Vec{X: 70, Y: 50}) {
+		t.Fatalf("unexpected stick state: value=%v knob=%v", stick.Value(), stick.KnobPos())
+	}
+
+	var info gamepadInfo
+	info.model = gamepadStandard
+	c.apply(&info)
+	if info.virtualButtons != 1<<ebiten.StandardGamepadButtonRightBottom {
+		t.Fatalf("unexpected virtual buttons: %b", info.virtualButtons)
+	}
+	if info.axisValues[ebiten.StandardGamepadAxisLeftStickHorizontal] != 1 {
+		t.Fatalf("unexpected axis values: %v", info.axisValues)
+	}
+
+	c.update(nil)
+	if stick.IsActive() || button.IsPressed() || stick.Value() != (Vec{}) {
+		t.Fatal("the controls are not released")
+	}
+}
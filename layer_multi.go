@@ -1,6 +1,9 @@
 package ge
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
 
 type MultiLayer struct {
 	List []SceneGraphicsLayer
@@ -36,3 +39,9 @@ func (l *MultiLayer) Draw(screen *ebiten.Image) {
 		l.List[i].Draw(screen)
 	}
 }
+
+func (l *MultiLayer) DrawWithOffset(screen *ebiten.Image, offset gmath.Vec) {
+	for i := range l.List {
+		drawGraphics(screen, l.List[i], offset)
+	}
+}
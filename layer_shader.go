@@ -2,6 +2,7 @@ package ge
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
 )
 
 type ShaderLayer struct {
@@ -34,6 +35,10 @@ func (l *ShaderLayer) IsDisposed() bool {
 }
 
 func (l *ShaderLayer) Draw(screen *ebiten.Image) {
+	l.DrawWithOffset(screen, gmath.Vec{})
+}
+
+func (l *ShaderLayer) DrawWithOffset(screen *ebiten.Image, offset gmath.Vec) {
 	if !l.Visible {
 		return
 	}
@@ -50,20 +55,27 @@ func (l *ShaderLayer) Draw(screen *ebiten.Image) {
 	shaderEnabled := l.Shader.Enabled && !l.Shader.IsNil()
 	if !shaderEnabled {
 		for _, g := range l.graphics {
-			g.Draw(screen)
+			drawGraphics(screen, g, offset)
 		}
 		return
 	}
 
-	if l.tmp == nil {
-		l.width, l.height = screen.Size()
+	// The screen size can change over time (e.g. when a post-processing
+	// buffer is used or the window layout changes), so the temporary
+	// image should follow it.
+	width, height := screen.Size()
+	if l.tmp == nil || l.width != width || l.height != height {
+		if l.tmp != nil {
+			l.tmp.Dispose()
+		}
+		l.width, l.height = width, height
 		l.tmp = ebiten.NewImage(l.width, l.height)
 	} else {
 		l.tmp.Clear()
 	}
 
 	for _, g := range l.graphics {
-		g.Draw(l.tmp)
+		drawGraphics(l.tmp, g, offset)
 	}
 
 	var options ebiten.DrawRectShaderOptions
@@ -71,5 +83,6 @@ func (l *ShaderLayer) Draw(screen *ebiten.Image) {
 	options.Images[1] = l.Shader.Texture1.Data
 	options.Images[2] = l.Shader.Texture2.Data
 	options.Images[3] = l.Shader.Texture3.Data
+	options.Uniforms = l.Shader.shaderData
 	screen.DrawRectShader(l.width, l.height, l.Shader.compiled, &options)
 }
@@ -2,6 +2,7 @@ package ge
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
 )
 
 type SimpleLayer struct {
@@ -26,6 +27,10 @@ func (l *SimpleLayer) IsDisposed() bool {
 }
 
 func (l *SimpleLayer) Draw(screen *ebiten.Image) {
+	l.DrawWithOffset(screen, gmath.Vec{})
+}
+
+func (l *SimpleLayer) DrawWithOffset(screen *ebiten.Image, offset gmath.Vec) {
 	if !l.Visible {
 		return
 	}
@@ -35,7 +40,7 @@ func (l *SimpleLayer) Draw(screen *ebiten.Image) {
 		if g.IsDisposed() {
 			continue
 		}
-		g.Draw(screen)
+		drawGraphics(screen, g, offset)
 		list = append(list, g)
 	}
 	l.graphics = list
@@ -4,6 +4,7 @@ import (
 	"sort"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
 )
 
 type YSortLayer struct {
@@ -39,6 +40,10 @@ func (l *YSortLayer) Dispose() {
 }
 
 func (l *YSortLayer) Draw(screen *ebiten.Image) {
+	l.DrawWithOffset(screen, gmath.Vec{})
+}
+
+func (l *YSortLayer) DrawWithOffset(screen *ebiten.Image, offset gmath.Vec) {
 	if !l.Visible {
 		return
 	}
@@ -76,7 +81,7 @@ func (l *YSortLayer) Draw(screen *ebiten.Image) {
 	// Do the actual rendering now.
 	// The slice should be in the correct order in respect to the Y coordinates.
 	for _, n := range list {
-		n.g.Draw(screen)
+		drawGraphics(screen, n.g, offset)
 	}
 
 	l.nodes.list = list
@@ -0,0 +1,426 @@
+package ge
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ge/internal/primitives"
+	"github.com/quasilyte/ge/physics"
+	"github.com/quasilyte/gmath"
+)
+
+type LightKind uint8
+
+const (
+	// LightPoint emits the light in all directions from its position.
+	LightPoint LightKind = iota
+
+	// LightSpot emits a cone of light from its position towards the Direction.
+	LightSpot
+
+	// LightDirectional lights the entire layer from the Direction,
+	// like a sun or a moon would do. Its position and radius are ignored.
+	LightDirectional
+)
+
+// Light is a light source that is rendered by the LightingLayer.
+type Light struct {
+	Kind LightKind
+
+	Pos Pos
+
+	Color ColorScale
+
+	// Intensity is a light color multiplier.
+	Intensity float64
+
+	// Radius is a distance at which the light fully fades out.
+	// Used by point and spot lights.
+	Radius float64
+
+	// Direction is an angle the light is pointed to.
+	// Used by spot and directional lights.
+	Direction gmath.Rad
+
+	// ConeAngle is a half-width of the spot light cone.
+	ConeAngle gmath.Rad
+
+	// Height is a distance between the light source and the layer plane.
+	// It affects the normal mapping: lower lights produce more contrast shading.
+	Height float64
+
+	// CastShadows enables the shadows casting from the layer occluders.
+	CastShadows bool
+
+	Enabled bool
+
+	disposed bool
+}
+
+// NewLight creates an enabled white light of the specified kind.
+func NewLight(kind LightKind) *Light {
+	return &Light{
+		Kind:        kind,
+		Color:       defaultColorScale,
+		Intensity:   1,
+		Radius:      128,
+		ConeAngle:   math.Pi / 6,
+		Height:      32,
+		CastShadows: true,
+		Enabled:     true,
+	}
+}
+
+func (l *Light) IsDisposed() bool { return l.disposed }
+
+func (l *Light) Dispose() { l.disposed = true }
+
+// LightOccluder is a shape that blocks the light.
+type LightOccluder interface {
+	IsDisposed() bool
+
+	// AppendVertices appends the occluder polygon vertices to dst.
+	// The polygon is implicitly closed.
+	AppendVertices(dst []gmath.Vec) []gmath.Vec
+}
+
+// RectOccluder is an axis-aligned rectangle light occluder.
+// It's a good fit for the walls and other solid tiles.
+type RectOccluder struct {
+	Rect gmath.Rect
+
+	disposed bool
+}
+
+func NewRectOccluder(rect gmath.Rect) *RectOccluder {
+	return &RectOccluder{Rect: rect}
+}
+
+func (o *RectOccluder) IsDisposed() bool { return o.disposed }
+
+func (o *RectOccluder) Dispose() { o.disposed = true }
+
+func (o *RectOccluder) AppendVertices(dst []gmath.Vec) []gmath.Vec {
+	return append(dst,
+		o.Rect.Min,
+		gmath.Vec{X: o.Rect.Max.X, Y: o.Rect.Min.Y},
+		o.Rect.Max,
+		gmath.Vec{X: o.Rect.Min.X, Y: o.Rect.Max.Y})
+}
+
+// NewTileOccluders creates the occluders for a tile grid.
+//
+// The solid func reports whether a tile at the specified grid cell blocks the light.
+// Adjacent solid tiles in a row are merged into a single occluder.
+func NewTileOccluders(numCols, numRows int, tileWidth, tileHeight float64, solid func(col, row int) bool) []*RectOccluder {
+	var occluders []*RectOccluder
+	for row := 0; row < numRows; row++ {
+		col := 0
+		for col < numCols {
+			if !solid(col, row) {
+				col++
+				continue
+			}
+			start := col
+			for col < numCols && solid(col, row) {
+				col++
+			}
+			occluders = append(occluders, NewRectOccluder(gmath.Rect{
+				Min: gmath.Vec{X: float64(start) * tileWidth, Y: float64(row) * tileHeight},
+				Max: gmath.Vec{X: float64(col) * tileWidth, Y: float64(row+1) * tileHeight},
+			}))
+		}
+	}
+	return occluders
+}
+
+type bodyOccluder struct {
+	body *physics.Body
+}
+
+// NewBodyOccluder creates a light occluder that follows the physics body shape.
+// Circle bodies are approximated by a polygon.
+//
+// The occluder is disposed together with the body.
+func NewBodyOccluder(b *physics.Body) LightOccluder {
+	return bodyOccluder{body: b}
+}
+
+func (o bodyOccluder) IsDisposed() bool { return o.body.IsDisposed() }
+
+func (o bodyOccluder) AppendVertices(dst []gmath.Vec) []gmath.Vec {
+	if o.body.IsRotatedRect() {
+		vertices := o.body.RotatedRectVertices()
+		return append(dst, vertices[:]...)
+	}
+	const numCircleVertices = 12
+	pos := o.body.Pos
+	r := o.body.CircleRadius()
+	for i := 0; i < numCircleVertices; i++ {
+		angle := gmath.Rad(2 * math.Pi * float64(i) / numCircleVertices)
+		dst = append(dst, pos.Add(gmath.RadToVec(angle).Mulf(r)))
+	}
+	return dst
+}
+
+// LightingLayer renders its graphics lit by the layer lights.
+//
+// The graphics are multiplied by the accumulated light that is a sum of the
+// Ambient color and all enabled lights contributions.
+// Sprites with a normal map (see Sprite.SetNormalMap) are shaded according to it.
+type LightingLayer struct {
+	Visible bool
+
+	// Ambient is the light level of the unlit areas.
+	// Its alpha component is ignored.
+	Ambient ColorScale
+
+	graphics  []SceneGraphics
+	lights    []*Light
+	occluders []LightOccluder
+
+	colorBuf  *ebiten.Image
+	normalBuf *ebiten.Image
+	lightBuf  *ebiten.Image
+	shadowBuf *ebiten.Image
+	width     int
+	height    int
+
+	lightShader     Shader
+	compositeShader Shader
+
+	polygon  []gmath.Vec
+	vertices []ebiten.Vertex
+	indices  []uint16
+
+	// drawShadowTriangles renders a shadow geometry batch.
+	// It's a field to make the batching testable without a GPU.
+	drawShadowTriangles func(vertices []ebiten.Vertex, indices []uint16)
+
+	disposed bool
+}
+
+type normalMapDrawer interface {
+	drawNormalMap(dst *ebiten.Image, offset gmath.Vec)
+}
+
+var flatNormalColor = color.RGBA{R: 128, G: 128, B: 255, A: 255}
+
+func NewLightingLayer() *LightingLayer {
+	l := &LightingLayer{
+		Visible:         true,
+		Ambient:         ColorScale{R: 0.1, G: 0.1, B: 0.1, A: 1},
+		graphics:        make([]SceneGraphics, 0, 8),
+		lightShader:     newBuiltinShader(shaderLight),
+		compositeShader: newBuiltinShader(shaderLightingComposite),
+	}
+	l.drawShadowTriangles = func(vertices []ebiten.Vertex, indices []uint16) {
+		var options ebiten.DrawTrianglesOptions
+		l.shadowBuf.DrawTriangles(vertices, indices, primitives.WhitePixel, &options)
+	}
+	return l
+}
+
+func (l *LightingLayer) AddGraphics(g SceneGraphics) {
+	l.graphics = append(l.graphics, g)
+}
+
+func (l *LightingLayer) AddLight(light *Light) {
+	l.lights = append(l.lights, light)
+}
+
+func (l *LightingLayer) AddOccluder(o LightOccluder) {
+	l.occluders = append(l.occluders, o)
+}
+
+func (l *LightingLayer) IsDisposed() bool {
+	return l.disposed
+}
+
+// Dispose marks the layer as disposed and releases its buffers.
+func (l *LightingLayer) Dispose() {
+	l.disposed = true
+	for _, img := range [...]*ebiten.Image{l.colorBuf, l.normalBuf, l.lightBuf, l.shadowBuf} {
+		if img != nil {
+			img.Dispose()
+		}
+	}
+	l.colorBuf = nil
+	l.normalBuf = nil
+	l.lightBuf = nil
+	l.shadowBuf = nil
+}
+
+func (l *LightingLayer) Draw(screen *ebiten.Image) {
+	l.DrawWithOffset(screen, gmath.Vec{})
+}
+
+func (l *LightingLayer) DrawWithOffset(screen *ebiten.Image, offset gmath.Vec) {
+	if !l.Visible {
+		return
+	}
+
+	l.removeDisposed()
+	l.resize(screen.Size())
+
+	l.colorBuf.Clear()
+	l.normalBuf.Fill(flatNormalColor)
+	for _, g := range l.graphics {
+		drawGraphics(l.colorBuf, g, offset)
+		if d, ok := g.(normalMapDrawer); ok {
+			d.drawNormalMap(l.normalBuf, offset)
+		}
+	}
+
+	l.lightBuf.Fill(color.RGBA{
+		R: uint8(gmath.Clamp(float64(l.Ambient.R), 0, 1) * 255),
+		G: uint8(gmath.Clamp(float64(l.Ambient.G), 0, 1) * 255),
+		B: uint8(gmath.Clamp(float64(l.Ambient.B), 0, 1) * 255),
+		A: 255,
+	})
+	for _, light := range l.lights {
+		if !light.Enabled {
+			continue
+		}
+		l.drawLight(light, offset)
+	}
+
+	var options ebiten.DrawRectShaderOptions
+	options.Images[0] = l.colorBuf
+	options.Images[1] = l.lightBuf
+	screen.DrawRectShader(l.width, l.height, l.compositeShader.compiled, &options)
+}
+
+func (l *LightingLayer) drawLight(light *Light, offset gmath.Vec) {
+	pos := light.Pos.Resolve().Add(offset)
+
+	l.shadowBuf.Clear()
+	if light.CastShadows {
+		l.drawShadows(light, pos, offset)
+	}
+
+	kind := float32(light.Kind)
+	dir := gmath.RadToVec(light.Direction)
+	s := &l.lightShader
+	s.setFloat32Value("Kind", kind)
+	s.SetVecValue("LightPos", pos)
+	s.SetColorValue("LightColor", light.Color)
+	s.SetFloatValue("Intensity", light.Intensity)
+	s.SetFloatValue("Radius", light.Radius)
+	s.SetVecValue("Direction", dir)
+	s.SetFloatValue("ConeCos", light.ConeAngle.Cos())
+	s.SetFloatValue("Height", light.Height)
+
+	var options ebiten.DrawRectShaderOptions
+	options.Blend = ebiten.BlendLighter
+	options.Images[0] = l.normalBuf
+	options.Images[1] = l.shadowBuf
+	options.Uniforms = s.shaderData
+	l.lightBuf.DrawRectShader(l.width, l.height, s.compiled, &options)
+}
+
+// drawShadows renders the shadow mask for the light into the shadow buffer.
+// Every occluder edge is extruded away from the light source.
+func (l *LightingLayer) drawShadows(light *Light, lightPos, offset gmath.Vec) {
+	// The extrusion length that is guaranteed to reach the buffer bounds.
+	far := float64(l.width + l.height)
+	if light.Kind != LightDirectional && light.Radius < far {
+		far = light.Radius * 2
+	}
+	dir := gmath.RadToVec(light.Direction)
+
+	l.vertices = l.vertices[:0]
+	l.indices = l.indices[:0]
+	for _, o := range l.occluders {
+		l.polygon = o.AppendVertices(l.polygon[:0])
+		for i, a := range l.polygon {
+			b := l.polygon[(i+1)%len(l.polygon)]
+			a = a.Add(offset)
+			b = b.Add(offset)
+			var aFar, bFar gmath.Vec
+			if light.Kind == LightDirectional {
+				aFar = a.Add(dir.Mulf(far))
+				bFar = b.Add(dir.Mulf(far))
+			} else {
+				aFar = a.Add(a.Sub(lightPos).Normalized().Mulf(far))
+				bFar = b.Add(b.Sub(lightPos).Normalized().Mulf(far))
+			}
+			l.appendQuad(a, b, bFar, aFar)
+		}
+	}
+	l.flushShadows()
+}
+
+func (l *LightingLayer) appendQuad(p0, p1, p2, p3 gmath.Vec) {
+	// The indices are uint16, so the batch is flushed before they overflow.
+	if len(l.vertices)+4 > math.MaxUint16+1 {
+		l.flushShadows()
+	}
+	i := uint16(len(l.vertices))
+	for _, p := range [4]gmath.Vec{p0, p1, p2, p3} {
+		l.vertices = append(l.vertices, ebiten.Vertex{
+			DstX:   float32(p.X),
+			DstY:   float32(p.Y),
+			SrcX:   1.5,
+			SrcY:   1.5,
+			ColorR: 1,
+			ColorG: 1,
+			ColorB: 1,
+			ColorA: 1,
+		})
+	}
+	l.indices = append(l.indices, i, i+1, i+2, i, i+2, i+3)
+}
+
+func (l *LightingLayer) flushShadows() {
+	if len(l.indices) == 0 {
+		return
+	}
+	l.drawShadowTriangles(l.vertices, l.indices)
+	l.vertices = l.vertices[:0]
+	l.indices = l.indices[:0]
+}
+
+func (l *LightingLayer) removeDisposed() {
+	graphics := l.graphics[:0]
+	for _, g := range l.graphics {
+		if !g.IsDisposed() {
+			graphics = append(graphics, g)
+		}
+	}
+	l.graphics = graphics
+
+	lights := l.lights[:0]
+	for _, light := range l.lights {
+		if !light.IsDisposed() {
+			lights = append(lights, light)
+		}
+	}
+	l.lights = lights
+
+	occluders := l.occluders[:0]
+	for _, o := range l.occluders {
+		if !o.IsDisposed() {
+			occluders = append(occluders, o)
+		}
+	}
+	l.occluders = occluders
+}
+
+func (l *LightingLayer) resize(width, height int) {
+	if l.colorBuf != nil && l.width == width && l.height == height {
+		return
+	}
+	for _, img := range [...]*ebiten.Image{l.colorBuf, l.normalBuf, l.lightBuf, l.shadowBuf} {
+		if img != nil {
+			img.Dispose()
+		}
+	}
+	l.width = width
+	l.height = height
+	l.colorBuf = ebiten.NewImage(width, height)
+	l.normalBuf = ebiten.NewImage(width, height)
+	l.lightBuf = ebiten.NewImage(width, height)
+	l.shadowBuf = ebiten.NewImage(width, height)
+}
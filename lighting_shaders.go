@@ -0,0 +1,59 @@
+package ge
+
+// Lighting layer shaders.
+// All of them use the pixels unit mode.
+
+// lightShaderSrc renders a single light contribution.
+//
+// Images[0] is a normal map buffer, Images[1] is a shadow mask buffer.
+// Kind values: 0 is a point light, 1 is a spot light, 2 is a directional light.
+const lightShaderSrc = `//kage:unit pixels
+
+package main
+
+var Kind float
+var LightPos vec2
+var LightColor vec3
+var Intensity float
+var Radius float
+var Direction vec2
+var ConeCos float
+var Height float
+
+func Fragment(dst vec4, src vec2, color vec4) vec4 {
+	n := normalize(imageSrc0At(src).xyz*2 - 1)
+	shadow := imageSrc1At(src).a
+
+	attenuation := 1.0
+	toLight := normalize(vec3(-Direction, Height))
+	if Kind < 1.5 {
+		d := LightPos - dst.xy
+		dist := length(d)
+		attenuation = clamp(1-dist/Radius, 0, 1)
+		attenuation *= attenuation
+		if Kind > 0.5 && dist > 0 {
+			cosAngle := dot(-d/dist, Direction)
+			attenuation *= smoothstep(ConeCos, mix(ConeCos, 1, 0.25), cosAngle)
+		}
+		toLight = normalize(vec3(d, Height))
+	}
+
+	diffuse := max(dot(n, toLight), 0)
+	k := Intensity * attenuation * diffuse * (1 - shadow)
+	return vec4(LightColor*k, 0)
+}
+`
+
+// lightingCompositeShaderSrc multiplies the rendered graphics by the accumulated light.
+//
+// Images[0] is a graphics buffer, Images[1] is a light buffer.
+const lightingCompositeShaderSrc = `//kage:unit pixels
+
+package main
+
+func Fragment(dst vec4, src vec2, color vec4) vec4 {
+	c := imageSrc0At(src)
+	l := imageSrc1At(src)
+	return vec4(c.rgb*l.rgb, c.a)
+}
+`
@@ -0,0 +1,81 @@
+package ge
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+func TestNewTileOccluders(t *testing.T) {
+	grid := []string{
+		"##..#",
+		".....",
+		".###.",
+	}
+	occluders := NewTileOccluders(5, 3, 16, 8, func(col, row int) bool {
+		return grid[row][col] == '#'
+	})
+
+	var have []string
+	for _, o := range occluders {
+		have = append(have, fmt.Sprintf("%g,%g-%g,%g", o.Rect.Min.X, o.Rect.Min.Y, o.Rect.Max.X, o.Rect.Max.Y))
+	}
+	want := []string{
+		"0,0-32,8",
+		"64,0-80,8",
+		"16,16-64,24",
+	}
+	if fmt.Sprint(have) != fmt.Sprint(want) {
+		t.Fatalf("occluders mismatch:\nhave: %v\nwant: %v", have, want)
+	}
+}
+
+type testPolygonOccluder struct {
+	vertices []gmath.Vec
+}
+
+func (o *testPolygonOccluder) IsDisposed() bool { return false }
+
+func (o *testPolygonOccluder) AppendVertices(dst []gmath.Vec) []gmath.Vec {
+	return append(dst, o.vertices...)
+}
+
+func TestLightingShadowsBatching(t *testing.T) {
+	// A single occluder that needs more vertices than uint16 indices can address.
+	const numEdges = 20000
+	o := &testPolygonOccluder{}
+	for i := 0; i < numEdges; i++ {
+		angle := gmath.Rad(2 * math.Pi * float64(i) / numEdges)
+		o.vertices = append(o.vertices, gmath.Vec{X: 500, Y: 500}.Add(gmath.RadToVec(angle).Mulf(100)))
+	}
+
+	l := &LightingLayer{width: 1000, height: 1000}
+	l.AddOccluder(o)
+	numBatches := 0
+	numVertices := 0
+	l.drawShadowTriangles = func(vertices []ebiten.Vertex, indices []uint16) {
+		numBatches++
+		numVertices += len(vertices)
+		if len(vertices) > math.MaxUint16+1 {
+			t.Fatalf("batch %d: too many vertices: %d", numBatches, len(vertices))
+		}
+		for _, index := range indices {
+			if int(index) >= len(vertices) {
+				t.Fatalf("batch %d: index %d is out of range [0, %d)", numBatches, index, len(vertices))
+			}
+		}
+	}
+
+	light := &Light{Kind: LightPoint, Radius: 300, CastShadows: true}
+	l.drawShadows(light, gmath.Vec{}, gmath.Vec{})
+
+	if numBatches != 2 {
+		t.Fatalf("expected 2 batches, got %d", numBatches)
+	}
+	if numVertices != numEdges*4 {
+		t.Fatalf("expected %d vertices, got %d", numEdges*4, numVertices)
+	}
+}
@@ -0,0 +1,244 @@
+package ge
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/gmath"
+)
+
+type NineSliceMode uint8
+
+const (
+	// NineSliceStretch scales the source region to fill the destination area.
+	NineSliceStretch NineSliceMode = iota
+
+	// NineSliceTile repeats the source region to fill the destination area.
+	// The last tile in a row (or column) is cropped.
+	NineSliceTile
+)
+
+// NineSliceInsets describe the fixed-size borders of a nine-slice image in pixels.
+type NineSliceInsets struct {
+	Left   int
+	Top    int
+	Right  int
+	Bottom int
+}
+
+// NineSlice renders an image at any size while keeping its corners intact.
+//
+// The source image is split into 9 regions by the insets.
+// The corners are drawn as is, the edges and the center
+// are stretched or tiled depending on the EdgeMode and CenterMode.
+type NineSlice struct {
+	Pos Pos
+
+	Width  float64
+	Height float64
+
+	Centered bool
+
+	Visible bool
+
+	// DrawCenter controls whether the center region is rendered.
+	// Disable it to get a frame-only image.
+	DrawCenter bool
+
+	EdgeMode   NineSliceMode
+	CenterMode NineSliceMode
+
+	colorScale       ColorScale
+	ebitenColorScale ebiten.ColorScale
+
+	image  *ebiten.Image
+	insets NineSliceInsets
+
+	// This is a scratch slice.
+	tiles []nineSliceTile
+
+	imageCache *imageCache
+
+	disposed bool
+}
+
+func NewNineSlice(ctx *Context, width, height float64) *NineSlice {
+	return &NineSlice{
+		Width:            width,
+		Height:           height,
+		Centered:         true,
+		Visible:          true,
+		DrawCenter:       true,
+		colorScale:       defaultColorScale,
+		ebitenColorScale: defaultColorScale.toEbitenColorScale(),
+		imageCache:       &ctx.imageCache,
+	}
+}
+
+// SetImage assigns the source image and its fixed borders sizes.
+func (s *NineSlice) SetImage(img resource.Image, insets NineSliceInsets) {
+	s.image = img.Data
+	s.insets = insets
+}
+
+func (s *NineSlice) Insets() NineSliceInsets { return s.insets }
+
+func (s *NineSlice) GetColorScale() ColorScale {
+	return s.colorScale
+}
+
+func (s *NineSlice) SetColorScale(colorScale ColorScale) {
+	if s.colorScale == colorScale {
+		return
+	}
+	s.colorScale = colorScale
+	s.ebitenColorScale = s.colorScale.toEbitenColorScale()
+}
+
+func (s *NineSlice) GetAlpha() float32 {
+	return s.colorScale.A
+}
+
+func (s *NineSlice) SetAlpha(a float32) {
+	if s.colorScale.A == a {
+		return
+	}
+	s.colorScale.A = a
+	s.ebitenColorScale = s.colorScale.toEbitenColorScale()
+}
+
+// AnchorPos returns a top-left position.
+// When Centered is false, it's identical to Pos, otherwise
+// it will apply the computations to get the right anchor for the centered image.
+func (s *NineSlice) AnchorPos() Pos {
+	if s.Centered {
+		return s.Pos.WithOffset(-s.Width/2, -s.Height/2)
+	}
+	return s.Pos
+}
+
+func (s *NineSlice) BoundsRect() gmath.Rect {
+	pos := s.AnchorPos().Resolve()
+	return gmath.Rect{
+		Min: pos,
+		Max: pos.Add(gmath.Vec{X: s.Width, Y: s.Height}),
+	}
+}
+
+func (s *NineSlice) IsDisposed() bool {
+	return s.disposed
+}
+
+func (s *NineSlice) Dispose() {
+	s.disposed = true
+}
+
+func (s *NineSlice) Draw(screen *ebiten.Image) {
+	s.DrawWithOffset(screen, gmath.Vec{})
+}
+
+func (s *NineSlice) DrawWithOffset(screen *ebiten.Image, offset gmath.Vec) {
+	if !s.Visible || s.image == nil || s.colorScale.A == 0 {
+		return
+	}
+
+	pos := s.AnchorPos().Resolve().Add(offset)
+	bounds := s.image.Bounds()
+
+	// Source and destination columns/rows boundaries.
+	srcX, dstX := nineSliceSplit(bounds.Min.X, bounds.Max.X, s.insets.Left, s.insets.Right, s.Width)
+	srcY, dstY := nineSliceSplit(bounds.Min.Y, bounds.Max.Y, s.insets.Top, s.insets.Bottom, s.Height)
+
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			isCenter := row == 1 && col == 1
+			if isCenter && !s.DrawCenter {
+				continue
+			}
+			src := image.Rect(srcX[col], srcY[row], srcX[col+1], srcY[row+1])
+			dst := gmath.Rect{
+				Min: gmath.Vec{X: pos.X + dstX[col], Y: pos.Y + dstY[row]},
+				Max: gmath.Vec{X: pos.X + dstX[col+1], Y: pos.Y + dstY[row+1]},
+			}
+			if src.Empty() || dst.Width() <= 0 || dst.Height() <= 0 {
+				continue
+			}
+			isCorner := row != 1 && col != 1
+			mode := s.EdgeMode
+			if isCenter {
+				mode = s.CenterMode
+			}
+			if isCorner || mode == NineSliceStretch {
+				s.drawStretched(screen, src, dst)
+			} else {
+				s.drawTiled(screen, src, dst)
+			}
+		}
+	}
+}
+
+func (s *NineSlice) drawStretched(screen *ebiten.Image, src image.Rectangle, dst gmath.Rect) {
+	var drawOptions ebiten.DrawImageOptions
+	drawOptions.ColorScale = s.ebitenColorScale
+	drawOptions.GeoM.Scale(dst.Width()/float64(src.Dx()), dst.Height()/float64(src.Dy()))
+	drawOptions.GeoM.Translate(dst.Min.X, dst.Min.Y)
+	screen.DrawImage(s.imageCache.UnsafeSubImage(s.image, src), &drawOptions)
+}
+
+func (s *NineSlice) drawTiled(screen *ebiten.Image, src image.Rectangle, dst gmath.Rect) {
+	var drawOptions ebiten.DrawImageOptions
+	drawOptions.ColorScale = s.ebitenColorScale
+	s.tiles = appendNineSliceTiles(s.tiles[:0], src, dst)
+	for _, tile := range s.tiles {
+		drawOptions.GeoM.Reset()
+		drawOptions.GeoM.Translate(tile.pos.X, tile.pos.Y)
+		screen.DrawImage(s.imageCache.UnsafeSubImage(s.image, tile.src), &drawOptions)
+	}
+}
+
+type nineSliceTile struct {
+	pos gmath.Vec
+	src image.Rectangle
+}
+
+// nineSliceSplit computes the source and destination boundaries along one axis.
+//
+// If the destination size is smaller than the insets sum,
+// the corners are scaled down proportionally and the middle part is empty.
+func nineSliceSplit(srcMin, srcMax, inset1, inset2 int, size float64) ([4]int, [4]float64) {
+	src := [4]int{srcMin, srcMin + inset1, srcMax - inset2, srcMax}
+	dst1 := float64(inset1)
+	dst2 := float64(inset2)
+	if total := dst1 + dst2; total > size && total > 0 {
+		scale := gmath.ClampMin(size, 0) / total
+		dst1 *= scale
+		dst2 *= scale
+	}
+	dst := [4]float64{0, dst1, size - dst2, size}
+	return src, dst
+}
+
+// appendNineSliceTiles covers the dst area with the src tiles.
+// The last tile in a row (or column) is cropped.
+func appendNineSliceTiles(tiles []nineSliceTile, src image.Rectangle, dst gmath.Rect) []nineSliceTile {
+	tileWidth := float64(src.Dx())
+	tileHeight := float64(src.Dy())
+	if tileWidth <= 0 || tileHeight <= 0 {
+		return tiles
+	}
+	for y := dst.Min.Y; y < dst.Max.Y; y += tileHeight {
+		h := gmath.ClampMax(tileHeight, dst.Max.Y-y)
+		for x := dst.Min.X; x < dst.Max.X; x += tileWidth {
+			w := gmath.ClampMax(tileWidth, dst.Max.X-x)
+			tile := src
+			tile.Max.X = src.Min.X + int(w+0.5)
+			tile.Max.Y = src.Min.Y + int(h+0.5)
+			if tile.Empty() {
+				continue
+			}
+			tiles = append(tiles, nineSliceTile{pos: gmath.Vec{X: x, Y: y}, src: tile})
+		}
+	}
+	return tiles
+}
@@ -0,0 +1,87 @@
+package ge
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestNineSliceSplit(t *testing.T) {
+	tests := []struct {
+		srcMin int
+		srcMax int
+		inset1 int
+		inset2 int
+		size   float64
+		src    [4]int
+		dst    [4]float64
+	}{
+		{0, 30, 10, 10, 100, [4]int{0, 10, 20, 30}, [4]float64{0, 10, 90, 100}},
+		{64, 96, 8, 4, 50, [4]int{64, 72, 92, 96}, [4]float64{0, 8, 46, 50}},
+		{0, 30, 0, 0, 50, [4]int{0, 0, 30, 30}, [4]float64{0, 0, 50, 50}},
+		{0, 30, 10, 10, 20, [4]int{0, 10, 20, 30}, [4]float64{0, 10, 10, 20}},
+
+		// The corners don't fit: they're scaled down.
+		{0, 40, 10, 30, 20, [4]int{0, 10, 10, 40}, [4]float64{0, 5, 5, 20}},
+		{0, 30, 10, 10, 0, [4]int{0, 10, 20, 30}, [4]float64{0, 0, 0, 0}},
+	}
+
+	for _, test := range tests {
+		src, dst := nineSliceSplit(test.srcMin, test.srcMax, test.inset1, test.inset2, test.size)
+		if src != test.src || dst != test.dst {
+			t.Fatalf("split([%d, %d], insets=%d,%d, size=%v):\nhave: %v %v\nwant: %v %v",
+				test.srcMin, test.srcMax, test.inset1, test.inset2, test.size, src, dst, test.src, test.dst)
+		}
+	}
+}
+
+func TestNineSliceTiles(t *testing.T) {
+	tests := []struct {
+		src  image.Rectangle
+		dst  gmath.Rect
+		want []string
+	}{
+		{
+			src: image.Rect(0, 0, 16, 8),
+			dst: gmath.Rect{Min: gmath.Vec{X: 100, Y: 50}, Max: gmath.Vec{X: 132, Y: 58}},
+			want: []string{
+				"100,50 (0,0)-(16,8)",
+				"116,50 (0,0)-(16,8)",
+			},
+		},
+		{
+			src: image.Rect(32, 16, 48, 24),
+			dst: gmath.Rect{Min: gmath.Vec{X: 100, Y: 50}, Max: gmath.Vec{X: 140, Y: 62}},
+			want: []string{
+				"100,50 (32,16)-(48,24)",
+				"116,50 (32,16)-(48,24)",
+				"132,50 (32,16)-(40,24)",
+				"100,58 (32,16)-(48,20)",
+				"116,58 (32,16)-(48,20)",
+				"132,58 (32,16)-(40,20)",
+			},
+		},
+		{
+			src:  image.Rect(0, 0, 16, 8),
+			dst:  gmath.Rect{Min: gmath.Vec{X: 10, Y: 10}, Max: gmath.Vec{X: 10.2, Y: 20}},
+			want: nil,
+		},
+		{
+			src:  image.Rect(0, 0, 0, 8),
+			dst:  gmath.Rect{Min: gmath.Vec{X: 10, Y: 10}, Max: gmath.Vec{X: 20, Y: 20}},
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		var have []string
+		for _, tile := range appendNineSliceTiles(nil, test.src, test.dst) {
+			have = append(have, fmt.Sprintf("%g,%g %v", tile.pos.X, tile.pos.Y, tile.src))
+		}
+		if fmt.Sprint(have) != fmt.Sprint(test.want) {
+			t.Fatalf("tiles(%v, %v) mismatch:\nhave: %v\nwant: %v", test.src, test.dst, have, test.want)
+		}
+	}
+}
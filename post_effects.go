@@ -0,0 +1,217 @@
+package ge
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// PostProcessing is an ordered chain of screen-wide shader passes.
+//
+// Every enabled pass receives the result of the previous pass
+// as its Images[0] (the first pass receives the rendered scene).
+// Shader textures are bound as Images[1], Images[2] and Images[3].
+// Passes with disabled shaders are skipped.
+//
+// The intermediate images are re-allocated automatically when
+// the screen size changes.
+type PostProcessing struct {
+	passes []*Shader
+
+	buffers [2]*ebiten.Image
+	width   int
+	height  int
+}
+
+// AddPass appends a shader pass to the end of the chain.
+// The shader is stored by pointer, so its uniforms can be
+// updated later and the changes will be visible during the next frame.
+func (pp *PostProcessing) AddPass(s *Shader) {
+	pp.passes = append(pp.passes, s)
+}
+
+// InsertPass adds a shader pass to the specified chain position.
+func (pp *PostProcessing) InsertPass(index int, s *Shader) {
+	pp.passes = append(pp.passes, nil)
+	copy(pp.passes[index+1:], pp.passes[index:])
+	pp.passes[index] = s
+}
+
+// RemovePass removes a shader pass from the chain.
+// It does nothing if s is not a part of this chain.
+func (pp *PostProcessing) RemovePass(s *Shader) {
+	for i, p := range pp.passes {
+		if p == s {
+			pp.passes = append(pp.passes[:i], pp.passes[i+1:]...)
+			return
+		}
+	}
+}
+
+// Passes returns the chain shaders in the order of their application.
+// The returned slice should not be modified.
+func (pp *PostProcessing) Passes() []*Shader {
+	return pp.passes
+}
+
+// Reset removes all passes from the chain.
+func (pp *PostProcessing) Reset() {
+	pp.passes = pp.passes[:0]
+}
+
+func (pp *PostProcessing) numActivePasses() int {
+	n := 0
+	for _, p := range pp.passes {
+		if p.Enabled && !p.IsNil() {
+			n++
+		}
+	}
+	return n
+}
+
+// begin returns an offscreen image that should be used as a scene render target.
+func (pp *PostProcessing) begin(screen *ebiten.Image) *ebiten.Image {
+	w, h := screen.Size()
+	if pp.buffers[0] == nil || pp.width != w || pp.height != h {
+		for i, b := range pp.buffers {
+			if b != nil {
+				b.Dispose()
+			}
+			pp.buffers[i] = ebiten.NewImage(w, h)
+		}
+		pp.width = w
+		pp.height = h
+	}
+	pp.buffers[0].Clear()
+	return pp.buffers[0]
+}
+
+// finish applies all active passes to the rendered scene.
+// The last pass is rendered directly to the screen.
+func (pp *PostProcessing) finish(screen *ebiten.Image, numPasses int) {
+	src := 0
+	for _, p := range pp.passes {
+		if !p.Enabled || p.IsNil() {
+			continue
+		}
+		numPasses--
+		dst := screen
+		if numPasses != 0 {
+			dst = pp.buffers[1-src]
+			dst.Clear()
+		}
+		var options ebiten.DrawRectShaderOptions
+		options.Images[0] = pp.buffers[src]
+		options.Images[1] = p.Texture1.Data
+		options.Images[2] = p.Texture2.Data
+		options.Images[3] = p.Texture3.Data
+		options.Uniforms = p.shaderData
+		dst.DrawRectShader(pp.width, pp.height, p.compiled, &options)
+		src = 1 - src
+	}
+}
+
+type builtinShaderKind int
+
+const (
+	shaderVignette builtinShaderKind = iota
+	shaderCRT
+	shaderColorGrading
+	shaderBlur
+	shaderBloom
+	shaderLight
+	shaderLightingComposite
+	numBuiltinShaders
+)
+
+var builtinShaders [numBuiltinShaders]*ebiten.Shader
+
+var builtinShaderSources = [numBuiltinShaders]string{
+	shaderVignette:     vignetteShaderSrc,
+	shaderCRT:          crtShaderSrc,
+	shaderColorGrading: colorGradingShaderSrc,
+	shaderBlur:         blurShaderSrc,
+	shaderBloom:        bloomShaderSrc,
+
+	shaderLight:             lightShaderSrc,
+	shaderLightingComposite: lightingCompositeShaderSrc,
+}
+
+func newBuiltinShader(kind builtinShaderKind) Shader {
+	compiled := builtinShaders[kind]
+	if compiled == nil {
+		var err error
+		compiled, err = ebiten.NewShader([]byte(builtinShaderSources[kind]))
+		if err != nil {
+			panic(fmt.Sprintf("compile builtin shader %d: %v", kind, err))
+		}
+		builtinShaders[kind] = compiled
+	}
+	return Shader{Enabled: true, compiled: compiled}
+}
+
+// NewVignetteShader creates a screen edges darkening effect.
+//
+// Uniforms:
+//   - Radius (0.75): the undarkened area radius, 0.5 is a half of the screen
+//   - Softness (0.45): the transition width
+//   - Intensity (1): the effect strength in [0, 1] range
+func NewVignetteShader() Shader {
+	s := newBuiltinShader(shaderVignette)
+	s.SetFloatValue("Radius", 0.75)
+	s.SetFloatValue("Softness", 0.45)
+	s.SetFloatValue("Intensity", 1)
+	return s
+}
+
+// NewCRTShader creates an old CRT monitor effect with a screen curvature and scanlines.
+//
+// Uniforms:
+//   - Curvature (0.03): the screen barrel distortion, 0 means a flat screen
+//   - ScanlineIntensity (0.25): how dark every second pixel row is, in [0, 1] range
+func NewCRTShader() Shader {
+	s := newBuiltinShader(shaderCRT)
+	s.SetFloatValue("Curvature", 0.03)
+	s.SetFloatValue("ScanlineIntensity", 0.25)
+	return s
+}
+
+// NewColorGradingShader creates a color adjustment effect.
+//
+// Uniforms:
+//   - Brightness (0): a value that is added to every color channel
+//   - Contrast (1): the contrast multiplier
+//   - Saturation (1): the saturation multiplier, 0 makes the image grayscale
+//   - Tint ({1, 1, 1}): the color channels multiplier, use SetColorValue to change it
+func NewColorGradingShader() Shader {
+	s := newBuiltinShader(shaderColorGrading)
+	s.SetFloatValue("Brightness", 0)
+	s.SetFloatValue("Contrast", 1)
+	s.SetFloatValue("Saturation", 1)
+	s.SetColorValue("Tint", defaultColorScale)
+	return s
+}
+
+// NewBlurShader creates a screen blur effect.
+//
+// Uniforms:
+//   - Radius (2): the blur radius in pixels
+func NewBlurShader() Shader {
+	s := newBuiltinShader(shaderBlur)
+	s.SetFloatValue("Radius", 2)
+	return s
+}
+
+// NewBloomShader creates a glow effect around the bright parts of the screen.
+//
+// Uniforms:
+//   - Threshold (0.7): the minimal pixel luminance that produces a glow
+//   - Intensity (0.6): the glow strength
+//   - Radius (4): the glow radius in pixels
+func NewBloomShader() Shader {
+	s := newBuiltinShader(shaderBloom)
+	s.SetFloatValue("Threshold", 0.7)
+	s.SetFloatValue("Intensity", 0.6)
+	s.SetFloatValue("Radius", 4)
+	return s
+}
@@ -0,0 +1,116 @@
+package ge
+
+// Built-in post-processing effects shaders.
+// All of them use the pixels unit mode.
+
+const vignetteShaderSrc = `//kage:unit pixels
+
+package main
+
+var Radius float
+var Softness float
+var Intensity float
+
+func Fragment(dst vec4, src vec2, color vec4) vec4 {
+	c := imageSrc0At(src)
+	uv := (src - imageSrc0Origin()) / imageSrc0Size()
+	d := distance(uv, vec2(0.5))
+	v := smoothstep(Radius, Radius-Softness, d)
+	k := mix(1.0, v, Intensity)
+	return vec4(c.rgb*k, c.a)
+}
+`
+
+const crtShaderSrc = `//kage:unit pixels
+
+package main
+
+var Curvature float
+var ScanlineIntensity float
+
+func Fragment(dst vec4, src vec2, color vec4) vec4 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	uv := (src-origin)/size*2 - 1
+	uv += uv * uv.yx * uv.yx * Curvature
+	uv = (uv + 1) / 2
+	if uv.x < 0 || uv.x > 1 || uv.y < 0 || uv.y > 1 {
+		return vec4(0)
+	}
+	c := imageSrc0At(uv*size + origin)
+	line := mod(floor(dst.y), 2)
+	k := 1 - ScanlineIntensity*line
+	return vec4(c.rgb*k, c.a)
+}
+`
+
+const colorGradingShaderSrc = `//kage:unit pixels
+
+package main
+
+var Brightness float
+var Contrast float
+var Saturation float
+var Tint vec3
+
+func Fragment(dst vec4, src vec2, color vec4) vec4 {
+	c := imageSrc0At(src)
+	if c.a == 0 {
+		return c
+	}
+	rgb := c.rgb / c.a
+	rgb = (rgb-0.5)*Contrast + 0.5 + Brightness
+	gray := dot(rgb, vec3(0.299, 0.587, 0.114))
+	rgb = mix(vec3(gray), rgb, Saturation)
+	rgb = clamp(rgb*Tint, 0, 1)
+	return vec4(rgb*c.a, c.a)
+}
+`
+
+const blurShaderSrc = `//kage:unit pixels
+
+package main
+
+var Radius float
+
+func Fragment(dst vec4, src vec2, color vec4) vec4 {
+	minPos := imageSrc0Origin()
+	maxPos := minPos + imageSrc0Size() - 1
+	stride := Radius / 2
+	sum := vec4(0)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			offset := vec2(float(i-2), float(j-2)) * stride
+			sum += imageSrc0At(clamp(src+offset, minPos, maxPos))
+		}
+	}
+	return sum / 25
+}
+`
+
+const bloomShaderSrc = `//kage:unit pixels
+
+package main
+
+var Threshold float
+var Intensity float
+var Radius float
+
+func Fragment(dst vec4, src vec2, color vec4) vec4 {
+	minPos := imageSrc0Origin()
+	maxPos := minPos + imageSrc0Size() - 1
+	stride := Radius / 2
+	glow := vec4(0)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			offset := vec2(float(i-2), float(j-2)) * stride
+			s := imageSrc0At(clamp(src+offset, minPos, maxPos))
+			lum := dot(s.rgb, vec3(0.299, 0.587, 0.114))
+			glow += s * step(Threshold, lum)
+		}
+	}
+	glow /= 25
+	c := imageSrc0At(src)
+	return vec4(clamp(c.rgb+glow.rgb*Intensity, 0, 1), c.a)
+}
+`
@@ -0,0 +1,201 @@
+package ge
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/gmath"
+)
+
+// RenderTarget draws its graphics into an offscreen image.
+//
+// The rendered image can be used as a texture (see Image and ResourceImage methods),
+// or it can be drawn as a regular scene graphics object.
+//
+// RenderTarget implements the SceneGraphicsLayer interface, so it can be
+// attached to a scene layer to capture all graphics of that layer.
+type RenderTarget struct {
+	Pos Pos
+
+	// Visible controls whether the rendered image is drawn to the destination.
+	// An invisible render target still renders its graphics,
+	// so it can be used as a texture source.
+	Visible bool
+
+	// Clear controls whether the image is cleared before the graphics are rendered.
+	Clear bool
+
+	// Shader is applied when the result image is drawn to the destination.
+	Shader Shader
+
+	colorScale       ColorScale
+	ebitenColorScale ebiten.ColorScale
+
+	width  int
+	height int
+	image  *ebiten.Image
+
+	graphics []SceneGraphics
+
+	disposed bool
+}
+
+// NewRenderTarget creates an offscreen render target of the specified size.
+//
+// A zero size means "use the destination image size";
+// the image is re-allocated automatically when that size changes.
+func NewRenderTarget(width, height int) *RenderTarget {
+	t := &RenderTarget{
+		Visible:          true,
+		Clear:            true,
+		colorScale:       defaultColorScale,
+		ebitenColorScale: defaultColorScale.toEbitenColorScale(),
+		width:            width,
+		height:           height,
+		graphics:         make([]SceneGraphics, 0, 8),
+	}
+	t.resize(width, height)
+	return t
+}
+
+// SetSize changes the render target image size.
+// See NewRenderTarget for the zero size semantics.
+func (t *RenderTarget) SetSize(width, height int) {
+	t.width = width
+	t.height = height
+	t.resize(width, height)
+}
+
+// Image returns the render target image.
+//
+// It returns nil if the target was not rendered yet and it has no fixed size.
+// Note that the image can be re-allocated after the resize, so it's
+// better to avoid keeping it around for too long.
+func (t *RenderTarget) Image() *ebiten.Image {
+	return t.image
+}
+
+// ResourceImage wraps the render target image into a resource.Image,
+// so it can be used with APIs like Sprite.SetImage.
+func (t *RenderTarget) ResourceImage() resource.Image {
+	return resource.Image{Data: t.image}
+}
+
+func (t *RenderTarget) GetColorScale() ColorScale {
+	return t.colorScale
+}
+
+func (t *RenderTarget) SetColorScale(colorScale ColorScale) {
+	if t.colorScale == colorScale {
+		return
+	}
+	t.colorScale = colorScale
+	t.ebitenColorScale = t.colorScale.toEbitenColorScale()
+}
+
+func (t *RenderTarget) GetAlpha() float32 {
+	return t.colorScale.A
+}
+
+func (t *RenderTarget) SetAlpha(a float32) {
+	if t.colorScale.A == a {
+		return
+	}
+	t.colorScale.A = a
+	t.ebitenColorScale = t.colorScale.toEbitenColorScale()
+}
+
+func (t *RenderTarget) AddGraphics(g SceneGraphics) {
+	t.graphics = append(t.graphics, g)
+}
+
+func (t *RenderTarget) IsDisposed() bool {
+	return t.disposed
+}
+
+// Dispose marks the render target as disposed and releases its image.
+// The graphics bound to this target are not disposed.
+func (t *RenderTarget) Dispose() {
+	t.disposed = true
+	if t.image != nil {
+		t.image.Dispose()
+		t.image = nil
+	}
+}
+
+// Render draws all target graphics into its image.
+//
+// It's called automatically by Draw, but it can be used to
+// update an invisible target that is not a part of the scene.
+func (t *RenderTarget) Render() {
+	t.render(gmath.Vec{})
+}
+
+func (t *RenderTarget) render(offset gmath.Vec) {
+	if t.image == nil {
+		return
+	}
+	if t.Clear {
+		t.image.Clear()
+	}
+	live := t.graphics[:0]
+	for _, g := range t.graphics {
+		if g.IsDisposed() {
+			continue
+		}
+		drawGraphics(t.image, g, offset)
+		live = append(live, g)
+	}
+	t.graphics = live
+}
+
+func (t *RenderTarget) resize(width, height int) {
+	if width == 0 || height == 0 {
+		return
+	}
+	if t.image != nil {
+		w, h := t.image.Size()
+		if w == width && h == height {
+			return
+		}
+		t.image.Dispose()
+	}
+	t.image = ebiten.NewImage(width, height)
+}
+
+func (t *RenderTarget) Draw(screen *ebiten.Image) {
+	t.DrawWithOffset(screen, gmath.Vec{})
+}
+
+// DrawWithOffset renders the target graphics and then draws the result image.
+// The offset is applied to the target graphics as well.
+func (t *RenderTarget) DrawWithOffset(screen *ebiten.Image, offset gmath.Vec) {
+	if t.width == 0 || t.height == 0 {
+		t.resize(screen.Size())
+	}
+	t.render(offset)
+
+	if !t.Visible || t.image == nil {
+		return
+	}
+
+	pos := t.Pos.Resolve()
+	shaderEnabled := t.Shader.Enabled && !t.Shader.IsNil()
+	if !shaderEnabled {
+		var options ebiten.DrawImageOptions
+		options.GeoM.Translate(pos.X, pos.Y)
+		options.ColorScale = t.ebitenColorScale
+		screen.DrawImage(t.image, &options)
+		return
+	}
+
+	w, h := t.image.Size()
+	var options ebiten.DrawRectShaderOptions
+	options.GeoM.Translate(pos.X, pos.Y)
+	options.ColorScale = t.ebitenColorScale
+	options.Images[0] = t.image
+	options.Images[1] = t.Shader.Texture1.Data
+	options.Images[2] = t.Shader.Texture2.Data
+	options.Images[3] = t.Shader.Texture3.Data
+	options.Uniforms = t.Shader.shaderData
+	screen.DrawRectShader(w, h, t.Shader.compiled, &options)
+}
@@ -0,0 +1,37 @@
+package ge
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type recordingGraphics struct {
+	dst []*ebiten.Image
+}
+
+func (g *recordingGraphics) Draw(dst *ebiten.Image) { g.dst = append(g.dst, dst) }
+
+func (g *recordingGraphics) IsDisposed() bool { return false }
+
+func TestRenderTargetFixedSize(t *testing.T) {
+	target := NewRenderTarget(32, 16)
+	img := target.Image()
+	if img == nil {
+		t.Fatal("fixed size target has no image")
+	}
+	if w, h := img.Size(); w != 32 || h != 16 {
+		t.Fatalf("image size mismatch: have %dx%d, want 32x16", w, h)
+	}
+
+	g := &recordingGraphics{}
+	target.AddGraphics(g)
+	screen := ebiten.NewImage(64, 64)
+	target.Draw(screen)
+	if len(g.dst) != 1 || g.dst[0] != img {
+		t.Fatalf("graphics are not rendered into the target image: %v", g.dst)
+	}
+	if target.Image() != img {
+		t.Fatal("fixed size target image was re-allocated")
+	}
+}
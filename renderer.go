@@ -1,8 +1,15 @@
 package ge
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
 
 type Renderer struct {
+	// PostProcessing is applied to the whole rendered scene.
+	// It's empty by default.
+	PostProcessing PostProcessing
+
 	op ebiten.DrawImageOptions
 }
 
@@ -10,25 +17,24 @@ func NewRenderer() *Renderer {
 	return &Renderer{}
 }
 
-func (r *Renderer) Draw(screen *ebiten.Image, graphics *[zindexMax][]SceneGraphics) {
-	for i, layerGraphics := range graphics {
-		if len(layerGraphics) != 0 {
-			graphics[i] = r.drawLayer(screen, layerGraphics)
-		}
+// Draw renders the scene layers in their z index order.
+// Camera-enabled layers are drawn with the camera offset applied.
+//
+// If there are any active post-processing passes, the scene is
+// rendered into an offscreen image first.
+func (r *Renderer) Draw(screen *ebiten.Image, layers []*SceneLayer, camera *Camera) {
+	numPasses := r.PostProcessing.numActivePasses()
+	dst := screen
+	if numPasses != 0 {
+		dst = r.PostProcessing.begin(screen)
 	}
-}
-
-func (r *Renderer) drawLayer(screen *ebiten.Image, graphics []SceneGraphics) []SceneGraphics {
-	liveGraphics := graphics[:0]
 
-	for _, g := range graphics {
-		if g.IsDisposed() {
-			continue
-		}
-
-		g.Draw(screen)
-		liveGraphics = append(liveGraphics, g)
+	offset := gmath.Vec{X: -camera.Pos.X, Y: -camera.Pos.Y}
+	for _, l := range layers {
+		l.draw(dst, offset)
 	}
 
-	return liveGraphics
+	if numPasses != 0 {
+		r.PostProcessing.finish(screen, numPasses)
+	}
 }
@@ -4,22 +4,22 @@ import (
 	"github.com/quasilyte/ge/physics"
 )
 
-const zindexMax = 6
-
 type RootScene struct {
 	context *Context
 
 	controller   SceneController
-	objects      []SceneObject
-	addedObjects []SceneObject
+	objects      [numUpdatePhases][]SceneObject
+	lateObjects  []lateObject
+	addedObjects []addedObject
 
 	delayedFuncs []delayedFunc
 
 	collisionEngine physics.CollisionEngine
 
-	graphics [zindexMax][]SceneGraphics
+	layers sceneLayerList
+	scenes map[int]*Scene
 
-	subSceneArray [zindexMax]Scene
+	camera Camera
 }
 
 type SimulationRunner struct {
@@ -34,34 +34,47 @@ func NewSimulatedScene(ctx *Context, controller SceneController) (*SimulationRun
 	root := newRootScene()
 	root.context = ctx
 	root.controller = controller
-	scene := &root.subSceneArray[1]
+	scene := root.sceneAt(0)
 	return &SimulationRunner{root: root}, scene
 }
 
 func newRootScene() *RootScene {
 	root := &RootScene{
-		objects:      make([]SceneObject, 0, 32),
-		addedObjects: make([]SceneObject, 0, 8),
-		graphics: [zindexMax][]SceneGraphics{
-			make([]SceneGraphics, 0, 16),
-			make([]SceneGraphics, 0, 24),
-			make([]SceneGraphics, 0, 8),
-		},
-	}
-	for i := range root.subSceneArray {
-		root.subSceneArray[i].zindex = uint8(i)
-		root.subSceneArray[i].root = root
+		addedObjects: make([]addedObject, 0, 8),
+		scenes:       make(map[int]*Scene, 4),
 	}
+	root.objects[UpdatePhaseDefault] = make([]SceneObject, 0, 32)
 	return root
 }
 
-func (scene *RootScene) addObject(o SceneObject, zindex uint) {
-	if zindex < zindexMax {
-		scene.addedObjects = append(scene.addedObjects, o)
-		o.Init(&scene.subSceneArray[zindex])
-		return
+// sceneAt returns a scene object that is bound to the given z index.
+// All graphics added via this scene will go to the associated layer.
+func (scene *RootScene) sceneAt(z int) *Scene {
+	s, ok := scene.scenes[z]
+	if !ok {
+		s = &Scene{root: scene, z: z}
+		scene.scenes[z] = s
 	}
-	panic("z index overflow")
+	return s
+}
+
+func (scene *RootScene) addObject(o SceneObject, z int) {
+	phase := UpdatePhaseDefault
+	if phased, ok := o.(PhasedSceneObject); ok {
+		phase = phased.UpdatePhase()
+	}
+	scene.addObjectWithPhase(o, z, phase)
+}
+
+func (scene *RootScene) addObjectWithPhase(o SceneObject, z int, phase UpdatePhase) {
+	if phase >= numUpdatePhases {
+		panic("invalid update phase")
+	}
+	// The object is not visible to the update loop until the end of the current frame.
+	// This gives the new objects a predictable first update frame
+	// regardless of the place where they were added.
+	scene.addedObjects = append(scene.addedObjects, addedObject{o: o, phase: phase})
+	o.Init(scene.sceneAt(z))
 }
 
 func (scene *RootScene) update(delta float64) {
@@ -82,17 +95,56 @@ func (scene *RootScene) update(delta float64) {
 
 	scene.controller.Update(delta)
 
-	liveObjects := scene.objects[:0]
-	for _, o := range scene.objects {
+	for phase := range scene.objects {
+		scene.objects[phase] = scene.updatePhase(scene.objects[phase], delta)
+	}
+
+	if len(scene.lateObjects) != 0 {
+		liveObjects := scene.lateObjects[:0]
+		for _, o := range scene.lateObjects {
+			if o.o.IsDisposed() {
+				continue
+			}
+			o.late.LateUpdate(delta)
+			liveObjects = append(liveObjects, o)
+		}
+		scene.lateObjects = liveObjects
+	}
+	if c, ok := scene.controller.(LateUpdater); ok {
+		c.LateUpdate(delta)
+	}
+
+	// Objects that were added during this frame will be
+	// updated for the first time during the next frame.
+	for _, added := range scene.addedObjects {
+		scene.objects[added.phase] = append(scene.objects[added.phase], added.o)
+		if late, ok := added.o.(LateUpdater); ok {
+			scene.lateObjects = append(scene.lateObjects, lateObject{o: added.o, late: late})
+		}
+	}
+	scene.addedObjects = scene.addedObjects[:0]
+}
+
+func (scene *RootScene) updatePhase(objects []SceneObject, delta float64) []SceneObject {
+	liveObjects := objects[:0]
+	for _, o := range objects {
 		if o.IsDisposed() {
 			continue
 		}
 		o.Update(delta)
 		liveObjects = append(liveObjects, o)
 	}
-	scene.objects = liveObjects
-	scene.objects = append(scene.objects, scene.addedObjects...)
-	scene.addedObjects = scene.addedObjects[:0]
+	return liveObjects
+}
+
+type addedObject struct {
+	o     SceneObject
+	phase UpdatePhase
+}
+
+type lateObject struct {
+	o    SceneObject
+	late LateUpdater
 }
 
 type delayedFunc struct {
@@ -29,9 +29,53 @@ type SceneObject interface {
 
 	// Update is called for every object during every logical game frame.
 	// Delta specifies how many seconds have passed from the previous frame.
+	//
+	// The objects are updated after the scene controller.
+	// The update order is defined by the object update phase (see UpdatePhase);
+	// objects inside the same phase are updated in the order they were added.
+	//
+	// An object that was added during the frame N will have its first
+	// Update call during the frame N+1, no matter whether it was
+	// added by a controller, other object or a delayed call.
 	Update(delta float64)
 }
 
+// UpdatePhase is used to order the scene objects update calls.
+//
+// All objects of the UpdatePhasePre are updated first,
+// then goes UpdatePhaseDefault and UpdatePhasePost objects.
+// A game could put its physics-like objects into the pre phase,
+// and the visual-only objects into the post phase.
+type UpdatePhase uint8
+
+const (
+	UpdatePhasePre UpdatePhase = iota
+	UpdatePhaseDefault
+	UpdatePhasePost
+
+	numUpdatePhases
+)
+
+// PhasedSceneObject is an optional SceneObject extension.
+//
+// Objects that don't implement this interface are updated during the UpdatePhaseDefault.
+// The phase is requested only once, when object is added to the scene.
+type PhasedSceneObject interface {
+	UpdatePhase() UpdatePhase
+}
+
+// LateUpdater is an optional SceneObject and SceneController extension.
+//
+// LateUpdate is called after all scene objects are updated during the current frame.
+// Objects' LateUpdate methods are called in the order they were added.
+// The controller LateUpdate is called after all objects LateUpdate methods.
+//
+// This is a good place to do things that depend on the final state
+// of other objects, like following some object with a camera.
+type LateUpdater interface {
+	LateUpdate(delta float64)
+}
+
 type SceneGraphics interface {
 	Draw(dst *ebiten.Image)
 
@@ -44,10 +88,17 @@ type SceneGraphicsLayer interface {
 	Draw(dst *ebiten.Image)
 }
 
+// Scene is a scene API handle bound to some z index.
+//
+// Objects added via this scene receive a scene handle with the same
+// z index during their Init, so AddGraphics will put their graphics
+// to the same layer. Use AddObjectAbove and AddGraphicsAbove
+// (and their Below counterparts) to use a layer relative to that z index.
 type Scene struct {
 	root *RootScene
 
-	zindex uint8
+	z     int
+	layer *SceneLayer
 }
 
 func (s *Scene) Context() *Context {
@@ -105,40 +156,86 @@ func (s *Scene) AddBody(b *physics.Body) {
 	s.root.collisionEngine.AddBody(b)
 }
 
-func (s *Scene) AddGraphics(g SceneGraphics) {
-	s.root.graphics[s.zindex] = append(s.root.graphics[s.zindex], g)
+// Z returns the z index this scene handle is bound to.
+func (s *Scene) Z() int {
+	return s.z
 }
 
-func (s *Scene) AddGraphicsAbove(g SceneGraphics, zindex uint8) {
-	z := int(s.zindex) + int(zindex)
-	if z > zindexMax {
-		panic("z index overflow")
-	}
-	s.root.graphics[z] = append(s.root.graphics[z], g)
+// Camera returns the scene camera.
+// Only the camera-enabled layers are affected by it (see SceneLayer).
+func (s *Scene) Camera() *Camera {
+	return &s.root.camera
+}
+
+// AddLayer creates a named layer with the specified z index.
+//
+// If there is an unnamed layer with the same z index, it's being
+// used as a named layer; there can be only one layer per z index.
+//
+// Named layers are usually created in the controller Init method.
+func (s *Scene) AddLayer(name string, z int) *SceneLayer {
+	return s.root.layers.AddNamed(name, z)
+}
+
+// Layer returns the named layer.
+// It panics if there is no layer with such name.
+func (s *Scene) Layer(name string) *SceneLayer {
+	return s.root.layers.GetNamed(name)
 }
 
-func (s *Scene) AddGraphicsBelow(g SceneGraphics, zindex uint8) {
-	z := int(s.zindex) - int(zindex)
-	if z < 0 {
-		panic("z index underflow")
+// LayerAt returns a layer with the specified z index.
+// The layer is created if it didn't exist before.
+func (s *Scene) LayerAt(z int) *SceneLayer {
+	return s.root.layers.Get(z)
+}
+
+// LayerScene returns a scene handle bound to the named layer z index.
+func (s *Scene) LayerScene(name string) *Scene {
+	return s.root.sceneAt(s.Layer(name).z)
+}
+
+func (s *Scene) AddGraphics(g SceneGraphics) {
+	if s.layer == nil {
+		s.layer = s.root.layers.Get(s.z)
 	}
-	s.root.graphics[z] = append(s.root.graphics[z], g)
+	s.layer.AddGraphics(g)
+}
+
+func (s *Scene) AddGraphicsAbove(g SceneGraphics, zindex int) {
+	s.root.layers.Get(s.z + zindex).AddGraphics(g)
+}
+
+func (s *Scene) AddGraphicsBelow(g SceneGraphics, zindex int) {
+	s.root.layers.Get(s.z - zindex).AddGraphics(g)
+}
+
+// AddGraphicsToLayer adds g to the named layer.
+func (s *Scene) AddGraphicsToLayer(g SceneGraphics, layer string) {
+	s.Layer(layer).AddGraphics(g)
 }
 
 func (scene *Scene) AddObject(o SceneObject) {
-	scene.root.addObject(o, uint(scene.zindex))
+	scene.root.addObject(o, scene.z)
 }
 
-func (scene *Scene) AddObjectAbove(o SceneObject, zindex uint8) {
-	scene.root.addObject(o, uint(scene.zindex+zindex))
+// AddObjectWithPhase is like AddObject, but it overrides the object update phase.
+// PhasedSceneObject interface is ignored for this object.
+func (scene *Scene) AddObjectWithPhase(o SceneObject, phase UpdatePhase) {
+	scene.root.addObjectWithPhase(o, scene.z, phase)
 }
 
-func (scene *Scene) AddObjectBelow(o SceneObject, zindex uint8) {
-	z := int(scene.zindex) - int(zindex)
-	if z < 0 {
-		panic("z index underflow")
-	}
-	scene.root.addObject(o, uint(z))
+func (scene *Scene) AddObjectAbove(o SceneObject, zindex int) {
+	scene.root.addObject(o, scene.z+zindex)
+}
+
+func (scene *Scene) AddObjectBelow(o SceneObject, zindex int) {
+	scene.root.addObject(o, scene.z-zindex)
+}
+
+// AddObjectToLayer is like AddObject, but the object will receive
+// a scene handle bound to the named layer.
+func (scene *Scene) AddObjectToLayer(o SceneObject, layer string) {
+	scene.root.addObject(o, scene.Layer(layer).z)
 }
 
 func (scene *Scene) DelayedCall(seconds float64, fn func()) {
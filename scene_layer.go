@@ -0,0 +1,172 @@
+package ge
+
+import (
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/gmath"
+)
+
+// SceneLayer is a z-ordered scene graphics container.
+//
+// Layers with a lower z index are drawn first.
+// Every numeric z index gets its own layer that is created on demand;
+// use Scene.AddLayer to give the layer a name.
+type SceneLayer struct {
+	// Visible controls whether the layer graphics are rendered.
+	Visible bool
+
+	// CameraEnabled controls whether the scene camera offset
+	// is applied to this layer graphics.
+	// Disable it for the HUD-like layers.
+	//
+	// Only the graphics that implement DrawWithOffset method
+	// (like Sprite, Rect and Label) can be moved by the camera.
+	CameraEnabled bool
+
+	name string
+	z    int
+
+	graphicsLayer SceneGraphicsLayer
+
+	graphics []SceneGraphics
+}
+
+// Camera describes the visible part of the scene.
+type Camera struct {
+	// Pos is the top-left corner of the camera view in the world coordinates.
+	Pos gmath.Vec
+}
+
+// offsetDrawer is implemented by graphics that can be drawn with the camera offset.
+type offsetDrawer interface {
+	DrawWithOffset(dst *ebiten.Image, offset gmath.Vec)
+}
+
+func newSceneLayer(z int) *SceneLayer {
+	return &SceneLayer{
+		Visible:       true,
+		CameraEnabled: true,
+		z:             z,
+		graphics:      make([]SceneGraphics, 0, 8),
+	}
+}
+
+// Name returns the layer name.
+// Layers that were created implicitly have an empty name.
+func (l *SceneLayer) Name() string { return l.name }
+
+// Z returns the layer z index.
+func (l *SceneLayer) Z() int { return l.z }
+
+// GraphicsLayer returns the attached graphics layer, if any.
+func (l *SceneLayer) GraphicsLayer() SceneGraphicsLayer { return l.graphicsLayer }
+
+// SetGraphicsLayer attaches a graphics layer like ShaderLayer or YSortLayer to this layer.
+//
+// All graphics that were added to this layer before are re-added to the attached layer.
+// All graphics added to this layer afterwards are also forwarded there.
+func (l *SceneLayer) SetGraphicsLayer(gl SceneGraphicsLayer) {
+	l.graphicsLayer = gl
+	for _, g := range l.graphics {
+		if g.IsDisposed() {
+			continue
+		}
+		gl.AddGraphics(g)
+	}
+	l.graphics = l.graphics[:0]
+}
+
+// AddGraphics adds g to this layer.
+func (l *SceneLayer) AddGraphics(g SceneGraphics) {
+	if l.graphicsLayer != nil {
+		l.graphicsLayer.AddGraphics(g)
+		return
+	}
+	l.graphics = append(l.graphics, g)
+}
+
+func (l *SceneLayer) draw(screen *ebiten.Image, offset gmath.Vec) {
+	if !l.Visible {
+		return
+	}
+	if !l.CameraEnabled {
+		offset = gmath.Vec{}
+	}
+
+	if len(l.graphics) != 0 {
+		liveGraphics := l.graphics[:0]
+		for _, g := range l.graphics {
+			if g.IsDisposed() {
+				continue
+			}
+			drawGraphics(screen, g, offset)
+			liveGraphics = append(liveGraphics, g)
+		}
+		l.graphics = liveGraphics
+	}
+
+	if l.graphicsLayer != nil {
+		drawGraphics(screen, l.graphicsLayer, offset)
+	}
+}
+
+func drawGraphics(screen *ebiten.Image, g interface{ Draw(*ebiten.Image) }, offset gmath.Vec) {
+	if !offset.IsZero() {
+		if d, ok := g.(offsetDrawer); ok {
+			d.DrawWithOffset(screen, offset)
+			return
+		}
+	}
+	g.Draw(screen)
+}
+
+type sceneLayerList struct {
+	list  []*SceneLayer
+	named map[string]*SceneLayer
+}
+
+func (layers *sceneLayerList) find(z int) int {
+	return sort.Search(len(layers.list), func(i int) bool {
+		return layers.list[i].z >= z
+	})
+}
+
+func (layers *sceneLayerList) Get(z int) *SceneLayer {
+	i := layers.find(z)
+	if i < len(layers.list) && layers.list[i].z == z {
+		return layers.list[i]
+	}
+	l := newSceneLayer(z)
+	layers.list = append(layers.list, nil)
+	copy(layers.list[i+1:], layers.list[i:])
+	layers.list[i] = l
+	return l
+}
+
+func (layers *sceneLayerList) GetNamed(name string) *SceneLayer {
+	l, ok := layers.named[name]
+	if !ok {
+		panic("undefined scene layer: " + name)
+	}
+	return l
+}
+
+func (layers *sceneLayerList) AddNamed(name string, z int) *SceneLayer {
+	if name == "" {
+		panic("empty scene layer name")
+	}
+	if _, ok := layers.named[name]; ok {
+		panic("redefined scene layer: " + name)
+	}
+	l := layers.Get(z)
+	if l.name != "" {
+		panic("scene layer " + name + " z index is already taken by " + l.name)
+	}
+	l.name = name
+	if layers.named == nil {
+		layers.named = make(map[string]*SceneLayer)
+	}
+	layers.named[name] = l
+	return l
+}
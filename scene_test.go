@@ -0,0 +1,113 @@
+package ge
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type testController struct {
+	log *[]string
+}
+
+func (c *testController) Init(scene *Scene) {}
+
+func (c *testController) Update(delta float64) {
+	*c.log = append(*c.log, "controller")
+}
+
+func (c *testController) LateUpdate(delta float64) {
+	*c.log = append(*c.log, "controller.late")
+}
+
+type testObject struct {
+	name     string
+	phase    UpdatePhase
+	log      *[]string
+	disposed bool
+	onUpdate func()
+}
+
+func (o *testObject) Init(scene *Scene) {}
+
+func (o *testObject) IsDisposed() bool { return o.disposed }
+
+func (o *testObject) UpdatePhase() UpdatePhase { return o.phase }
+
+func (o *testObject) Update(delta float64) {
+	*o.log = append(*o.log, o.name)
+	if o.onUpdate != nil {
+		o.onUpdate()
+	}
+}
+
+type testLateObject struct {
+	testObject
+}
+
+func (o *testLateObject) LateUpdate(delta float64) {
+	*o.log = append(*o.log, o.name+".late")
+}
+
+func TestUpdateOrder(t *testing.T) {
+	var log []string
+	runner, scene := NewSimulatedScene(nil, &testController{log: &log})
+
+	spawned := &testObject{name: "spawned", phase: UpdatePhasePre, log: &log}
+	spawn := true
+	scene.AddObject(&testObject{name: "post", phase: UpdatePhasePost, log: &log})
+	scene.AddObject(&testLateObject{testObject{name: "default1", phase: UpdatePhaseDefault, log: &log}})
+	scene.AddObject(&testObject{name: "pre", phase: UpdatePhasePre, log: &log, onUpdate: func() {
+		if spawn {
+			spawn = false
+			scene.AddObject(spawned)
+		}
+	}})
+	scene.AddObjectWithPhase(&testObject{name: "default2", phase: UpdatePhasePre, log: &log}, UpdatePhaseDefault)
+
+	tests := []string{
+		// Objects that were added before the first frame are
+		// updated starting from the next frame.
+		"controller controller.late",
+
+		// The phases order is respected.
+		"controller pre default1 default2 post default1.late controller.late",
+
+		// The object spawned during the frame 2 is updated starting from the frame 3.
+		"controller pre spawned default1 default2 post default1.late controller.late",
+	}
+
+	for i, want := range tests {
+		log = log[:0]
+		runner.Update(1.0 / 60.0)
+		have := strings.Join(log, " ")
+		if have != want {
+			t.Fatalf("frame %d:\nhave: %s\nwant: %s", i+1, have, want)
+		}
+	}
+}
+
+func TestSceneLayerList(t *testing.T) {
+	var layers sceneLayerList
+	for _, z := range []int{3, -1, 0, 10, 3, -5, 0} {
+		layers.Get(z)
+	}
+	hud := layers.AddNamed("hud", 100)
+	background := layers.AddNamed("background", -1)
+
+	var have []int
+	for _, l := range layers.list {
+		have = append(have, l.Z())
+	}
+	want := []int{-5, -1, 0, 3, 10, 100}
+	if fmt.Sprint(have) != fmt.Sprint(want) {
+		t.Fatalf("z order mismatch:\nhave: %v\nwant: %v", have, want)
+	}
+
+	if layers.GetNamed("hud") != hud || layers.Get(100) != hud {
+		t.Fatalf("hud layer lookup failed")
+	}
+	if layers.GetNamed("background") != background || layers.Get(-1) != background {
+		t.Fatalf("background layer lookup failed")
+	}
+}
@@ -0,0 +1,113 @@
+package sfx
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Waveform uint8
+
+const (
+	WaveSquare Waveform = iota
+	WaveSawtooth
+	WaveSine
+	WaveTriangle
+	WaveNoise
+)
+
+var waveformNames = [...]string{
+	WaveSquare:   "square",
+	WaveSawtooth: "sawtooth",
+	WaveSine:     "sine",
+	WaveTriangle: "triangle",
+	WaveNoise:    "noise",
+}
+
+func (w Waveform) String() string {
+	if int(w) < len(waveformNames) {
+		return waveformNames[w]
+	}
+	return fmt.Sprintf("Waveform(%d)", w)
+}
+
+func (w Waveform) MarshalText() ([]byte, error) {
+	if int(w) >= len(waveformNames) {
+		return nil, fmt.Errorf("invalid waveform: %d", w)
+	}
+	return []byte(waveformNames[w]), nil
+}
+
+func (w *Waveform) UnmarshalText(text []byte) error {
+	for i, name := range waveformNames {
+		if name == string(text) {
+			*w = Waveform(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown waveform: %q", text)
+}
+
+// Params describe a sound effect.
+//
+// All durations are in seconds, all frequencies are in Hz.
+// The zero value of every optional field disables the related feature.
+type Params struct {
+	Waveform Waveform `json:"waveform"`
+
+	// Volume is a sound amplitude multiplier, usually in [0, 1] range.
+	Volume float64 `json:"volume"`
+
+	// Seed is used to generate the noise waveform.
+	// Sounds generated from the same params are always identical.
+	Seed int64 `json:"seed,omitempty"`
+
+	// The envelope: the volume goes from 0 to 1 during the Attack,
+	// stays at 1 during the Sustain and then goes down to 0 during the Decay.
+	// Punch (0-1) adds an extra volume at the beginning of the Sustain stage.
+	Attack  float64 `json:"attack"`
+	Sustain float64 `json:"sustain"`
+	Punch   float64 `json:"punch,omitempty"`
+	Decay   float64 `json:"decay"`
+
+	// Frequency is a starting tone frequency.
+	Frequency float64 `json:"frequency"`
+
+	// MinFrequency stops the sound when the frequency slides below it.
+	MinFrequency float64 `json:"min_frequency,omitempty"`
+
+	// Slide is a frequency change speed in octaves per second.
+	// Positive values make the tone go higher, negative values make it go lower.
+	// SlideAccel is a Slide change speed (octaves per second squared).
+	Slide      float64 `json:"slide,omitempty"`
+	SlideAccel float64 `json:"slide_accel,omitempty"`
+
+	// VibratoDepth (0-1) is a relative frequency deviation of the vibrato.
+	// VibratoSpeed is a vibrato frequency.
+	VibratoDepth float64 `json:"vibrato_depth,omitempty"`
+	VibratoSpeed float64 `json:"vibrato_speed,omitempty"`
+
+	// ArpeggioMod is a frequency multiplier that is applied once
+	// after ArpeggioTime. Values above 1 make the tone jump up.
+	ArpeggioMod  float64 `json:"arpeggio_mod,omitempty"`
+	ArpeggioTime float64 `json:"arpeggio_time,omitempty"`
+
+	// Duty (0-1) is a square wave duty cycle; zero is interpreted as 0.5.
+	// DutySweep is a duty cycle change speed (per second).
+	Duty      float64 `json:"duty,omitempty"`
+	DutySweep float64 `json:"duty_sweep,omitempty"`
+}
+
+// Duration returns the sound duration (without the MinFrequency cutoff effect).
+func (p *Params) Duration() float64 {
+	return p.Attack + p.Sustain + p.Decay
+}
+
+// UnmarshalParams decodes the params from a JSON-encoded data.
+// The params can be encoded with the json.Marshal function.
+func UnmarshalParams(jsonData []byte) (Params, error) {
+	p := Params{Volume: 1}
+	if err := json.Unmarshal(jsonData, &p); err != nil {
+		return p, err
+	}
+	return p, nil
+}
@@ -0,0 +1,90 @@
+package sfx
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+// The preset functions below return randomized params for the common sound effects.
+// The results are deterministic: the same rand state produces the same params.
+
+// Pickup returns a short bright "coin" sound, usually with an arpeggio jump.
+func Pickup(r *gmath.Rand) Params {
+	p := Params{
+		Waveform:  Waveform(r.IntRange(int(WaveSquare), int(WaveSine))),
+		Volume:    0.5,
+		Seed:      randSeed(r),
+		Sustain:   r.FloatRange(0.02, 0.1),
+		Punch:     r.FloatRange(0.3, 0.6),
+		Decay:     r.FloatRange(0.1, 0.3),
+		Frequency: r.FloatRange(600, 1400),
+		Duty:      r.FloatRange(0.3, 0.5),
+	}
+	if r.Bool() {
+		p.ArpeggioMod = r.FloatRange(1.25, 1.6)
+		p.ArpeggioTime = r.FloatRange(0.03, 0.08)
+	}
+	return p
+}
+
+// Laser returns a "pew" sound with a fast descending frequency slide.
+func Laser(r *gmath.Rand) Params {
+	p := Params{
+		Waveform:     Waveform(r.IntRange(int(WaveSquare), int(WaveSine))),
+		Volume:       0.5,
+		Seed:         randSeed(r),
+		Sustain:      r.FloatRange(0.05, 0.15),
+		Decay:        r.FloatRange(0.05, 0.2),
+		Frequency:    r.FloatRange(800, 2000),
+		MinFrequency: r.FloatRange(80, 200),
+		Slide:        r.FloatRange(-16, -6),
+		Duty:         r.FloatRange(0.2, 0.5),
+		DutySweep:    r.FloatRange(-1, 1),
+	}
+	if r.Chance(0.3) {
+		p.Punch = r.FloatRange(0.2, 0.5)
+	}
+	return p
+}
+
+// Explosion returns a noisy low-pitched boom.
+func Explosion(r *gmath.Rand) Params {
+	p := Params{
+		Waveform:  WaveNoise,
+		Volume:    0.6,
+		Seed:      randSeed(r),
+		Sustain:   r.FloatRange(0.05, 0.3),
+		Punch:     r.FloatRange(0.2, 0.8),
+		Decay:     r.FloatRange(0.3, 0.8),
+		Frequency: r.FloatRange(40, 300),
+		Slide:     r.FloatRange(-3, 0.5),
+	}
+	if r.Chance(0.3) {
+		p.VibratoDepth = r.FloatRange(0.1, 0.5)
+		p.VibratoSpeed = r.FloatRange(5, 20)
+	}
+	return p
+}
+
+// Hit returns a short punchy "damage" sound.
+func Hit(r *gmath.Rand) Params {
+	p := Params{
+		Waveform:  Waveform(r.IntRange(int(WaveSquare), int(WaveSawtooth))),
+		Volume:    0.5,
+		Seed:      randSeed(r),
+		Sustain:   r.FloatRange(0.01, 0.05),
+		Decay:     r.FloatRange(0.05, 0.2),
+		Frequency: r.FloatRange(150, 700),
+		Slide:     r.FloatRange(-12, -4),
+		Duty:      r.FloatRange(0.3, 0.5),
+	}
+	if r.Bool() {
+		p.Waveform = WaveNoise
+	}
+	return p
+}
+
+func randSeed(r *gmath.Rand) int64 {
+	return int64(r.IntRange(0, math.MaxInt32))
+}
@@ -0,0 +1,109 @@
+package sfx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestGenerateDeterministic(t *testing.T) {
+	presets := []struct {
+		name string
+		fn   func(r *gmath.Rand) Params
+	}{
+		{"pickup", Pickup},
+		{"laser", Laser},
+		{"explosion", Explosion},
+		{"hit", Hit},
+	}
+
+	for _, preset := range presets {
+		var r1, r2 gmath.Rand
+		r1.SetSeed(42)
+		r2.SetSeed(42)
+		p1 := preset.fn(&r1)
+		p2 := preset.fn(&r2)
+		if p1 != p2 {
+			t.Fatalf("%s: params mismatch:\n%+v\n%+v", preset.name, p1, p2)
+		}
+		data1 := Generate(p1, 44100)
+		data2 := Generate(p2, 44100)
+		if len(data1) == 0 {
+			t.Fatalf("%s: empty sound", preset.name)
+		}
+		if !bytes.Equal(data1, data2) {
+			t.Fatalf("%s: generated data mismatch", preset.name)
+		}
+		if len(data1)%BytesPerFrame != 0 {
+			t.Fatalf("%s: incomplete frame", preset.name)
+		}
+	}
+}
+
+func TestGenerateLength(t *testing.T) {
+	p := Params{
+		Waveform:  WaveSine,
+		Volume:    1,
+		Attack:    0.1,
+		Sustain:   0.2,
+		Decay:     0.2,
+		Frequency: 440,
+	}
+	if have, want := len(Generate(p, 1000)), 500*BytesPerFrame; have != want {
+		t.Fatalf("length mismatch: have %d, want %d", have, want)
+	}
+
+	// The frequency goes down 1 octave in 0.1 seconds, so 440 => 220 cutoff
+	// should stop the sound earlier.
+	p.Slide = -10
+	p.MinFrequency = 220
+	if have := len(Generate(p, 1000)); have > 101*BytesPerFrame {
+		t.Fatalf("the sound was not stopped by the min frequency: %d frames", have/BytesPerFrame)
+	}
+}
+
+func TestParamsJSON(t *testing.T) {
+	var r gmath.Rand
+	r.SetSeed(1)
+	p := Laser(&r)
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := UnmarshalParams(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded != p {
+		t.Fatalf("params mismatch after decoding:\n%+v\n%+v", decoded, p)
+	}
+
+	decoded, err = UnmarshalParams([]byte(`{"waveform": "noise", "sustain": 0.5}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Waveform != WaveNoise || decoded.Volume != 1 {
+		t.Fatalf("unexpected decoding result: %+v", decoded)
+	}
+
+	if _, err := UnmarshalParams([]byte(`{"waveform": "piano"}`)); err == nil {
+		t.Fatal("expected an error for unknown waveform")
+	}
+}
+
+func TestEncodeWAV(t *testing.T) {
+	pcm := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	data := EncodeWAV(pcm, 44100)
+	if string(data[:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		t.Fatal("invalid WAV header")
+	}
+	if sampleRate := binary.LittleEndian.Uint32(data[24:]); sampleRate != 44100 {
+		t.Fatalf("invalid sample rate: %d", sampleRate)
+	}
+	if !bytes.Equal(data[44:], pcm) {
+		t.Fatal("invalid WAV data")
+	}
+}
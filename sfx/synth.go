@@ -0,0 +1,164 @@
+package sfx
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+// BytesPerFrame is a size of a single PCM frame produced by the Generate function.
+// Every frame is a pair of 16-bit little-endian signed samples (left and right channels).
+const BytesPerFrame = 4
+
+// Generate synthesizes a sound effect as a 16-bit stereo PCM data.
+//
+// This is the format used by the Ebitengine audio package, so the result
+// can be played with audio.Context.NewPlayerFromBytes or registered
+// as a sound via AudioSystem.SetSoundData (the sampleRate should
+// match the audio context sample rate).
+func Generate(p Params, sampleRate int) []byte {
+	numFrames := int(p.Duration() * float64(sampleRate))
+	data := make([]byte, numFrames*BytesPerFrame)
+
+	s := newSynth(&p, sampleRate)
+	for i := 0; i < numFrames; i++ {
+		v, ok := s.next()
+		if !ok {
+			// The sound was stopped by the MinFrequency cutoff.
+			return data[:i*BytesPerFrame]
+		}
+		sample := uint16(pcmSample(v))
+		binary.LittleEndian.PutUint16(data[i*BytesPerFrame:], sample)
+		binary.LittleEndian.PutUint16(data[i*BytesPerFrame+2:], sample)
+	}
+
+	return data
+}
+
+// noiseBufferSize is a number of random values per the noise wave period.
+const noiseBufferSize = 32
+
+type synth struct {
+	p  *Params
+	dt float64
+
+	t     float64
+	phase float64
+	freq  float64
+	slide float64
+	duty  float64
+
+	arpeggioApplied bool
+
+	rand  gmath.Rand
+	noise [noiseBufferSize]float64
+}
+
+func newSynth(p *Params, sampleRate int) *synth {
+	s := &synth{
+		p:     p,
+		dt:    1 / float64(sampleRate),
+		freq:  p.Frequency,
+		slide: p.Slide,
+		duty:  p.Duty,
+	}
+	if s.duty == 0 {
+		s.duty = 0.5
+	}
+	s.rand.SetSeed(p.Seed)
+	s.fillNoise()
+	return s
+}
+
+func (s *synth) fillNoise() {
+	for i := range s.noise {
+		s.noise[i] = s.rand.FloatRange(-1, 1)
+	}
+}
+
+func (s *synth) next() (float64, bool) {
+	p := s.p
+
+	if !s.arpeggioApplied && p.ArpeggioMod != 0 && s.t >= p.ArpeggioTime {
+		s.arpeggioApplied = true
+		s.freq *= p.ArpeggioMod
+	}
+	if s.slide != 0 || p.SlideAccel != 0 {
+		s.freq *= math.Exp2(s.slide * s.dt)
+		s.slide += p.SlideAccel * s.dt
+	}
+	if p.MinFrequency != 0 && s.freq < p.MinFrequency {
+		return 0, false
+	}
+	if p.DutySweep != 0 {
+		s.duty = gmath.Clamp(s.duty+p.DutySweep*s.dt, 0.05, 0.95)
+	}
+
+	freq := s.freq
+	if p.VibratoDepth != 0 {
+		freq *= 1 + p.VibratoDepth*math.Sin(2*math.Pi*p.VibratoSpeed*s.t)
+	}
+
+	v := s.wave() * s.envelope() * p.Volume
+
+	s.phase += freq * s.dt
+	if s.phase >= 1 {
+		s.phase -= math.Floor(s.phase)
+		if p.Waveform == WaveNoise {
+			s.fillNoise()
+		}
+	}
+	s.t += s.dt
+
+	return v, true
+}
+
+func (s *synth) wave() float64 {
+	switch s.p.Waveform {
+	case WaveSawtooth:
+		return 1 - 2*s.phase
+	case WaveSine:
+		return math.Sin(2 * math.Pi * s.phase)
+	case WaveTriangle:
+		if s.phase < 0.5 {
+			return 4*s.phase - 1
+		}
+		return 3 - 4*s.phase
+	case WaveNoise:
+		return s.noise[int(s.phase*noiseBufferSize)%noiseBufferSize]
+	default:
+		if s.phase < s.duty {
+			return 0.5
+		}
+		return -0.5
+	}
+}
+
+func (s *synth) envelope() float64 {
+	p := s.p
+	t := s.t
+	if t < p.Attack {
+		return t / p.Attack
+	}
+	t -= p.Attack
+	if t < p.Sustain {
+		return 1 + p.Punch*2*(1-t/p.Sustain)
+	}
+	t -= p.Sustain
+	if t < p.Decay {
+		return 1 - t/p.Decay
+	}
+	return 0
+}
+
+func pcmSample(v float64) int16 {
+	v *= math.MaxInt16
+	if v > math.MaxInt16 {
+		return math.MaxInt16
+	}
+	if v < math.MinInt16 {
+		return math.MinInt16
+	}
+	return int16(v)
+}
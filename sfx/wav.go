@@ -0,0 +1,37 @@
+package sfx
+
+import (
+	"encoding/binary"
+)
+
+// EncodeWAV wraps the PCM data produced by Generate into a WAV container.
+//
+// It can be used to save the generated sounds to files or to serve them
+// via resource.Loader.OpenAssetFunc like any other WAV asset.
+func EncodeWAV(pcm []byte, sampleRate int) []byte {
+	const (
+		headerSize    = 44
+		numChannels   = 2
+		bitsPerSample = 16
+	)
+
+	data := make([]byte, headerSize+len(pcm))
+	copy(data[0:], "RIFF")
+	binary.LittleEndian.PutUint32(data[4:], uint32(headerSize-8+len(pcm)))
+	copy(data[8:], "WAVE")
+
+	copy(data[12:], "fmt ")
+	binary.LittleEndian.PutUint32(data[16:], 16) // fmt chunk size
+	binary.LittleEndian.PutUint16(data[20:], 1)  // PCM format
+	binary.LittleEndian.PutUint16(data[22:], numChannels)
+	binary.LittleEndian.PutUint32(data[24:], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(data[28:], uint32(sampleRate*BytesPerFrame))
+	binary.LittleEndian.PutUint16(data[32:], BytesPerFrame)
+	binary.LittleEndian.PutUint16(data[34:], bitsPerSample)
+
+	copy(data[36:], "data")
+	binary.LittleEndian.PutUint32(data[40:], uint32(len(pcm)))
+	copy(data[headerSize:], pcm)
+
+	return data
+}
@@ -31,6 +31,24 @@ func (s *Shader) SetFloatValue(key string, v float64) {
 	s.setFloat32Value(key, float32(v))
 }
 
+// SetVecValue assigns a vec2 uniform value.
+func (s *Shader) SetVecValue(key string, v gmath.Vec) {
+	s.setValue(key, []float32{float32(v.X), float32(v.Y)})
+}
+
+// SetColorValue assigns a vec3 uniform value using the color RGB components.
+// The alpha component is ignored.
+func (s *Shader) SetColorValue(key string, c ColorScale) {
+	s.setValue(key, []float32{c.R, c.G, c.B})
+}
+
+func (s *Shader) setValue(key string, v any) {
+	if s.shaderData == nil {
+		s.shaderData = make(map[string]any, 2)
+	}
+	s.shaderData[key] = v
+}
+
 func (s *Shader) setFloat32Value(key string, v float32) {
 	if oldValue, ok := s.shaderData[key].(float32); ok && oldValue == v {
 		return
@@ -45,6 +63,8 @@ type Sprite struct {
 	image *ebiten.Image
 	id    resource.ImageID
 
+	normalMap *ebiten.Image
+
 	Pos      Pos
 	Rotation *gmath.Rad
 
@@ -202,6 +222,19 @@ func (s *Sprite) SetRepeatedImage(img resource.Image, width, height float64) {
 	}
 }
 
+// SetNormalMap assigns a normal map texture that is used by the LightingLayer.
+// The normal map should have the same layout (size and frames) as the sprite image.
+//
+// Note that the normals are not adjusted for the sprite rotation and flips.
+func (s *Sprite) SetNormalMap(img resource.Image) {
+	s.normalMap = img.Data
+}
+
+// NormalMap returns the sprite normal map texture, if any.
+func (s *Sprite) NormalMap() *ebiten.Image {
+	return s.normalMap
+}
+
 // AnchorPos returns a top-left position.
 // When Centered is false, it's identical to Pos, otherwise
 // it will apply the computations to get the right anchor for the centered sprite.
@@ -256,6 +289,41 @@ func (s *Sprite) DrawWithOffset(screen *ebiten.Image, offset gmath.Vec) {
 
 	var drawOptions ebiten.DrawImageOptions
 	drawOptions.ColorScale = s.ebitenColorScale
+	drawOptions.GeoM = s.calculateGeom(offset)
+
+	srcImage, srcBounds := s.frameImage(s.image)
+
+	shaderEnabled := s.Shader.Enabled && !s.Shader.IsNil()
+	if !shaderEnabled {
+		screen.DrawImage(srcImage, &drawOptions)
+	} else {
+		var options ebiten.DrawRectShaderOptions
+		options.GeoM = drawOptions.GeoM
+		options.ColorScale = drawOptions.ColorScale
+		options.CompositeMode = drawOptions.CompositeMode
+		options.Images[0] = srcImage
+		options.Images[1] = s.Shader.Texture1.Data
+		options.Images[2] = s.Shader.Texture2.Data
+		options.Images[3] = s.Shader.Texture3.Data
+		options.Uniforms = s.Shader.shaderData
+		screen.DrawRectShader(srcBounds.Dx(), srcBounds.Dy(), s.Shader.compiled, &options)
+	}
+}
+
+// drawNormalMap draws the sprite normal map using the same transformations
+// as DrawWithOffset would use for the sprite image.
+func (s *Sprite) drawNormalMap(dst *ebiten.Image, offset gmath.Vec) {
+	if !s.Visible || s.normalMap == nil {
+		return
+	}
+	var drawOptions ebiten.DrawImageOptions
+	drawOptions.GeoM = s.calculateGeom(offset)
+	srcImage, _ := s.frameImage(s.normalMap)
+	dst.DrawImage(srcImage, &drawOptions)
+}
+
+func (s *Sprite) calculateGeom(offset gmath.Vec) ebiten.GeoM {
+	var geom ebiten.GeoM
 
 	var origin gmath.Vec
 	if s.Centered {
@@ -264,69 +332,55 @@ func (s *Sprite) DrawWithOffset(screen *ebiten.Image, offset gmath.Vec) {
 	origin = origin.Sub(s.Pos.Offset)
 
 	if s.FlipHorizontal {
-		drawOptions.GeoM.Scale(-1, 1)
-		drawOptions.GeoM.Translate(s.FrameWidth, 0)
+		geom.Scale(-1, 1)
+		geom.Translate(s.FrameWidth, 0)
 	}
 	if s.FlipVertical {
-		drawOptions.GeoM.Scale(1, -1)
-		drawOptions.GeoM.Translate(0, s.FrameHeight)
+		geom.Scale(1, -1)
+		geom.Translate(0, s.FrameHeight)
 	}
 
-	drawOptions.GeoM.Translate(-origin.X, -origin.Y)
+	geom.Translate(-origin.X, -origin.Y)
 	if s.Rotation != nil {
-		drawOptions.GeoM.Rotate(float64(*s.Rotation))
+		geom.Rotate(float64(*s.Rotation))
 	}
 	if s.scaleX != 1 || s.scaleY != 1 {
-		drawOptions.GeoM.Scale(s.scaleX, s.scaleY)
+		geom.Scale(s.scaleX, s.scaleY)
 	}
-	drawOptions.GeoM.Translate(origin.X, origin.Y)
+	geom.Translate(origin.X, origin.Y)
 
 	if s.Pos.Base != nil {
-		drawOptions.GeoM.Translate(s.Pos.Base.X-origin.X, s.Pos.Base.Y-origin.Y)
+		geom.Translate(s.Pos.Base.X-origin.X, s.Pos.Base.Y-origin.Y)
 	} else if !origin.IsZero() {
-		drawOptions.GeoM.Translate(0-origin.X, 0-origin.Y)
+		geom.Translate(0-origin.X, 0-origin.Y)
 	}
-	drawOptions.GeoM.Translate(offset.X, offset.Y)
+	geom.Translate(offset.X, offset.Y)
+
+	return geom
+}
 
-	var srcImage *ebiten.Image
-	var srcBounds image.Rectangle
+// frameImage returns the current frame sub-image of img.
+// img is expected to have the same layout as the sprite image.
+func (s *Sprite) frameImage(img *ebiten.Image) (*ebiten.Image, image.Rectangle) {
 	needSubImage := (s.FrameOffset != gmath.Vec{}) ||
 		s.FrameTrimTop != 0 ||
 		s.FrameTrimBottom != 0 ||
 		s.FrameWidth != s.imageWidth ||
 		s.FrameHeight != s.imageHeight
-	if needSubImage {
-		srcBounds = image.Rectangle{
-			Min: image.Point{
-				X: int(s.FrameOffset.X),
-				Y: int(s.FrameOffset.Y + s.FrameTrimTop),
-			},
-			Max: image.Point{
-				X: int(s.FrameOffset.X + s.FrameWidth),
-				Y: int(s.FrameOffset.Y + s.FrameHeight - s.FrameTrimBottom),
-			},
-		}
-		srcImage = s.imageCache.UnsafeSubImage(s.image, srcBounds)
-	} else {
-		srcImage = s.image
-		srcBounds = s.image.Bounds()
+	if !needSubImage {
+		return img, img.Bounds()
 	}
-
-	shaderEnabled := s.Shader.Enabled && !s.Shader.IsNil()
-	if !shaderEnabled {
-		screen.DrawImage(srcImage, &drawOptions)
-	} else {
-		var options ebiten.DrawRectShaderOptions
-		options.GeoM = drawOptions.GeoM
-		options.ColorScale = drawOptions.ColorScale
-		options.CompositeMode = drawOptions.CompositeMode
-		options.Images[0] = srcImage
-		options.Images[1] = s.Shader.Texture1.Data
-		options.Images[2] = s.Shader.Texture2.Data
-		options.Images[3] = s.Shader.Texture3.Data
-		options.Uniforms = s.Shader.shaderData
-		screen.DrawRectShader(srcBounds.Dx(), srcBounds.Dy(), s.Shader.compiled, &options)
+	srcBounds := image.Rectangle{
+		Min: image.Point{
+			X: int(s.FrameOffset.X),
+			Y: int(s.FrameOffset.Y + s.FrameTrimTop),
+		},
+		Max: image.Point{
+			X: int(s.FrameOffset.X + s.FrameWidth),
+			Y: int(s.FrameOffset.Y + s.FrameHeight - s.FrameTrimBottom),
+		},
 	}
+	return s.imageCache.UnsafeSubImage(img, srcBounds), srcBounds
 }
 
 var tmpImage = ebiten.NewImage(64, 64)
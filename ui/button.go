@@ -24,8 +24,9 @@ type Button struct {
 	style              ButtonStyle
 
 	label *ge.Label
-	rect  *ge.Rect
+	bg    *background
 
+	size gmath.Vec
 	geom gmath.Rect
 
 	root *Root
@@ -50,6 +51,15 @@ type ButtonStyle struct {
 	DisabledBorderColor     ge.ColorScale
 	DisabledBackgroundColor ge.ColorScale
 	DisabledTextColor       ge.ColorScale
+
+	// Frame replaces the rect background with a textured frame.
+	// The background colors are used as the frame color scale then,
+	// the border settings are ignored.
+	Frame *Frame
+
+	// FocusedFrame is an optional frame that is used instead of the Frame
+	// while the button is focused.
+	FocusedFrame *Frame
 }
 
 func DefaultButtonStyle() ButtonStyle {
@@ -79,32 +89,42 @@ func (style ButtonStyle) Resized(w, h float64) ButtonStyle {
 }
 
 func (b *Button) Init(scene *ge.Scene) {
-	pos := b.Pos.Resolve()
-	b.geom = gmath.Rect{
-		Min: pos,
-		Max: pos.Add(gmath.Vec{X: b.style.Width, Y: b.style.Height}),
+	if b.size.IsZero() {
+		b.size = b.preferredSize()
 	}
+	b.geom = widgetRect(b.Pos, b.size)
 
-	b.rect = ge.NewRect(b.root.ctx, b.style.Width, b.style.Height)
-	b.rect.FillColorScale = b.style.BackgroundColor
-	b.rect.OutlineColorScale = b.style.BorderColor
-	b.rect.OutlineWidth = b.style.BorderWidth
-	b.rect.Pos = b.Pos
-	b.rect.Centered = false
-	b.rect.Visible = b.Visible
-	scene.AddGraphics(b.rect)
+	b.bg = newBackground(scene, b.Pos, b.size.X, b.size.Y, b.style.BorderWidth, b.style.Frame, b.style.FocusedFrame)
+	b.bg.SetColors(b.style.BackgroundColor, b.style.BorderColor)
+	b.bg.SetVisible(b.Visible)
 
 	b.label = scene.NewLabel(b.style.Font)
-	b.label.Width = b.style.Width
-	b.label.Height = b.style.Height
+	b.label.Width = b.size.X
+	b.label.Height = b.size.Y
 	b.label.AlignHorizontal = ge.AlignHorizontalCenter
 	b.label.AlignVertical = ge.AlignVerticalCenter
 	b.label.Pos = b.Pos
-	b.label.ColorScale = b.style.TextColor
+	b.label.SetColorScale(b.style.TextColor)
 	b.label.Visible = b.Visible
 	scene.AddGraphics(b.label)
 }
 
+func (b *Button) preferredSize() gmath.Vec {
+	return gmath.Vec{X: b.style.Width, Y: b.style.Height}
+}
+
+func (b *Button) setLayout(pos ge.Pos, size gmath.Vec) {
+	b.Pos = pos
+	b.size = size
+	if b.label == nil {
+		return // Will be applied during the Init
+	}
+	b.label.Pos = pos
+	b.label.Width = size.X
+	b.label.Height = size.Y
+	b.bg.SetRect(pos, size)
+}
+
 func (b *Button) prevInput() inputElement     { return b.PrevInput }
 func (b *Button) nextInput() inputElement     { return b.NextInput }
 func (b *Button) setPrevInput(e inputElement) { b.PrevInput = e }
@@ -121,29 +141,27 @@ func (b *Button) SetDisabled(disabled bool) {
 	b.disabled = disabled
 	if b.disabled {
 		b.SetFocus(false)
-		b.label.ColorScale = b.style.DisabledTextColor
-		b.rect.FillColorScale = b.style.DisabledBackgroundColor
-		b.rect.OutlineColorScale = b.style.DisabledBorderColor
+		b.label.SetColorScale(b.style.DisabledTextColor)
+		b.bg.SetColors(b.style.DisabledBackgroundColor, b.style.DisabledBorderColor)
 	} else {
-		b.label.ColorScale = b.style.TextColor
-		b.rect.FillColorScale = b.style.BackgroundColor
-		b.rect.OutlineColorScale = b.style.BorderColor
+		b.label.SetColorScale(b.style.TextColor)
+		b.bg.SetColors(b.style.BackgroundColor, b.style.BorderColor)
 	}
 }
 
 func (b *Button) onFocusChanged(focused bool) {
+	b.bg.SetFocused(focused)
 	if focused {
-		b.label.ColorScale = b.style.FocusedTextColor
-		b.rect.FillColorScale = b.style.FocusedBackgroundColor
-		b.rect.OutlineColorScale = b.style.FocusedBorderColor
+		b.label.SetColorScale(b.style.FocusedTextColor)
+		b.bg.SetColors(b.style.FocusedBackgroundColor, b.style.FocusedBorderColor)
 	} else {
-		b.label.ColorScale = b.style.TextColor
-		b.rect.FillColorScale = b.style.BackgroundColor
-		b.rect.OutlineColorScale = b.style.BorderColor
+		b.label.SetColorScale(b.style.TextColor)
+		b.bg.SetColors(b.style.BackgroundColor, b.style.BorderColor)
 	}
 }
 
 func (b *Button) Update(delta float64) {
+	b.geom = widgetRect(b.Pos, b.size)
 	if !b.disabled {
 		if b.geom.Contains(b.root.input.CursorPos()) {
 			b.root.setFocus(b, true)
@@ -153,19 +171,19 @@ func (b *Button) Update(delta float64) {
 
 	b.label.Text = b.Text
 	b.label.Visible = b.Visible
-	b.rect.Visible = b.Visible
+	b.bg.SetVisible(b.Visible)
 
 	b.checkKeyboardInput = b.IsFocused()
 }
 
 func (b *Button) IsDisposed() bool {
-	return b.rect.IsDisposed()
+	return b.bg.IsDisposed()
 }
 
 func (b *Button) Dispose() {
 	b.eventDisposed.Emit(b)
 	b.label.Dispose()
-	b.rect.Dispose()
+	b.bg.Dispose()
 }
 
 func (b *Button) Activate() {
@@ -0,0 +1,262 @@
+package ui
+
+import (
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/gesignal"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+)
+
+// Checkbox is a labeled on/off toggle.
+//
+// It's activated like a button; every activation flips the checked state.
+type Checkbox struct {
+	Visible bool
+
+	Pos  ge.Pos
+	Text string
+
+	PrevInput inputElement
+	NextInput inputElement
+
+	// EventToggled is emitted with the new checked state.
+	EventToggled  gesignal.Event[bool]
+	eventDisposed gesignal.Event[*Checkbox]
+
+	checkKeyboardInput bool
+	disabled           bool
+	checked            bool
+	style              CheckboxStyle
+
+	label *ge.Label
+	box   *background
+	mark  *ge.Rect
+
+	size gmath.Vec
+	geom gmath.Rect
+
+	root *Root
+}
+
+type CheckboxStyle struct {
+	Width  float64
+	Height float64
+
+	// BoxSize is a checkbox square side length.
+	BoxSize float64
+
+	BorderWidth float64
+
+	Font resource.FontID
+
+	BorderColor     ge.ColorScale
+	BackgroundColor ge.ColorScale
+	MarkColor       ge.ColorScale
+	TextColor       ge.ColorScale
+
+	FocusedBorderColor ge.ColorScale
+	FocusedTextColor   ge.ColorScale
+
+	DisabledBorderColor ge.ColorScale
+	DisabledTextColor   ge.ColorScale
+
+	// Frame replaces the rect box with a textured frame.
+	Frame *Frame
+}
+
+func DefaultCheckboxStyle() CheckboxStyle {
+	return CheckboxStyle{
+		Width:       256,
+		Height:      48,
+		BoxSize:     32,
+		BorderWidth: 1,
+
+		BorderColor:     grayColor,
+		BackgroundColor: darkGrayColor,
+		MarkColor:       whiteColor,
+		TextColor:       grayColor,
+
+		FocusedBorderColor: whiteColor,
+		FocusedTextColor:   whiteColor,
+
+		DisabledBorderColor: withAlpha(grayColor, 0.8),
+		DisabledTextColor:   withAlpha(grayColor, 0.8),
+	}
+}
+
+func (style CheckboxStyle) Resized(w, h float64) CheckboxStyle {
+	style.Width = w
+	style.Height = h
+	return style
+}
+
+func (r *Root) NewCheckbox(style CheckboxStyle) *Checkbox {
+	e := &Checkbox{
+		style:   style,
+		Visible: true,
+		root:    r,
+	}
+	e.eventDisposed.Connect(r, func(b *Checkbox) {
+		if r.disposed {
+			return
+		}
+		r.inputElems = xslices.RemoveIf(r.inputElems, func(e inputElement) bool {
+			return b == e
+		})
+	})
+	r.inputElems = append(r.inputElems, e)
+	return e
+}
+
+func (b *Checkbox) Init(scene *ge.Scene) {
+	if b.size.IsZero() {
+		b.size = b.preferredSize()
+	}
+	b.geom = widgetRect(b.Pos, b.size)
+
+	boxPos, markPos := b.boxPositions()
+	boxSize := gmath.Vec{X: b.style.BoxSize, Y: b.style.BoxSize}
+	b.box = newBackground(scene, boxPos, boxSize.X, boxSize.Y, b.style.BorderWidth, b.style.Frame, nil)
+
+	b.mark = ge.NewRect(scene.Context(), b.style.BoxSize/2, b.style.BoxSize/2)
+	b.mark.Centered = false
+	b.mark.Pos = markPos
+	b.mark.FillColorScale = b.style.MarkColor
+	scene.AddGraphics(b.mark)
+
+	b.label = scene.NewLabel(b.style.Font)
+	b.label.AlignVertical = ge.AlignVerticalCenter
+	scene.AddGraphics(b.label)
+	b.placeLabel()
+
+	b.updateColors()
+}
+
+func (b *Checkbox) preferredSize() gmath.Vec {
+	return gmath.Vec{X: b.style.Width, Y: b.style.Height}
+}
+
+func (b *Checkbox) setLayout(pos ge.Pos, size gmath.Vec) {
+	b.Pos = pos
+	b.size = size
+	if b.label == nil {
+		return // Will be applied during the Init
+	}
+	boxPos, markPos := b.boxPositions()
+	b.box.SetRect(boxPos, gmath.Vec{X: b.style.BoxSize, Y: b.style.BoxSize})
+	b.mark.Pos = markPos
+	b.placeLabel()
+}
+
+func (b *Checkbox) boxPositions() (ge.Pos, ge.Pos) {
+	dy := (b.size.Y - b.style.BoxSize) / 2
+	boxPos := b.Pos.WithOffset(0, dy)
+	markPos := boxPos.WithOffset(b.style.BoxSize/4, b.style.BoxSize/4)
+	return boxPos, markPos
+}
+
+func (b *Checkbox) placeLabel() {
+	// The text is placed to the right of the box.
+	textOffset := b.style.BoxSize + b.style.BoxSize/2
+	b.label.Pos = b.Pos.WithOffset(textOffset, 0)
+	b.label.Width = b.size.X - textOffset
+	b.label.Height = b.size.Y
+}
+
+func (b *Checkbox) prevInput() inputElement     { return b.PrevInput }
+func (b *Checkbox) nextInput() inputElement     { return b.NextInput }
+func (b *Checkbox) setPrevInput(e inputElement) { b.PrevInput = e }
+func (b *Checkbox) setNextInput(e inputElement) { b.NextInput = e }
+
+func (b *Checkbox) IsDisabled() bool      { return b.disabled }
+func (b *Checkbox) IsFocused() bool       { return b.root.focused == b }
+func (b *Checkbox) SetFocus(focused bool) { b.root.setFocus(b, focused) }
+
+func (b *Checkbox) IsChecked() bool { return b.checked }
+
+// SetChecked changes the checkbox state without emitting the EventToggled.
+func (b *Checkbox) SetChecked(checked bool) { b.checked = checked }
+
+func (b *Checkbox) SetDisabled(disabled bool) {
+	if b.disabled == disabled {
+		return
+	}
+	b.disabled = disabled
+	if b.disabled {
+		b.SetFocus(false)
+	}
+	b.updateColors()
+}
+
+func (b *Checkbox) onFocusChanged(focused bool) {
+	b.updateColors()
+}
+
+func (b *Checkbox) updateColors() {
+	if b.label == nil {
+		return
+	}
+	switch {
+	case b.disabled:
+		b.label.SetColorScale(b.style.DisabledTextColor)
+		b.box.SetColors(b.style.BackgroundColor, b.style.DisabledBorderColor)
+	case b.IsFocused():
+		b.label.SetColorScale(b.style.FocusedTextColor)
+		b.box.SetColors(b.style.BackgroundColor, b.style.FocusedBorderColor)
+	default:
+		b.label.SetColorScale(b.style.TextColor)
+		b.box.SetColors(b.style.BackgroundColor, b.style.BorderColor)
+	}
+}
+
+func (b *Checkbox) Update(delta float64) {
+	b.geom = widgetRect(b.Pos, b.size)
+	if !b.disabled {
+		if b.geom.Contains(b.root.input.CursorPos()) {
+			b.root.setFocus(b, true)
+		}
+		b.checkInput()
+	}
+
+	b.label.Text = b.Text
+	b.label.Visible = b.Visible
+	b.box.SetVisible(b.Visible)
+	b.mark.Visible = b.Visible && b.checked
+
+	b.checkKeyboardInput = b.IsFocused()
+}
+
+func (b *Checkbox) IsDisposed() bool {
+	return b.box.IsDisposed()
+}
+
+func (b *Checkbox) Dispose() {
+	b.eventDisposed.Emit(b)
+	b.label.Dispose()
+	b.box.Dispose()
+	b.mark.Dispose()
+}
+
+// Toggle flips the checked state as if the checkbox was activated.
+func (b *Checkbox) Toggle() {
+	if b.disabled {
+		return
+	}
+	b.checked = !b.checked
+	b.EventToggled.Emit(b.checked)
+}
+
+func (b *Checkbox) checkInput() {
+	if b.root.ActivationAction == actionUnset {
+		return
+	}
+	if info, ok := b.root.input.JustPressedActionInfo(b.root.ActivationAction); ok {
+		if !b.checkKeyboardInput && !info.IsTouchEvent() {
+			return
+		}
+		if !info.HasPos() || b.geom.Contains(info.Pos) {
+			b.Toggle()
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+)
+
+// Frame is a textured widget background that is rendered as ge.NineSlice.
+type Frame struct {
+	Texture resource.Image
+	Insets  ge.NineSliceInsets
+
+	EdgeMode   ge.NineSliceMode
+	CenterMode ge.NineSliceMode
+}
+
+// background is a widget background that is either
+// a colored rect or a textured frame.
+type background struct {
+	rect  *ge.Rect
+	slice *ge.NineSlice
+
+	frame        *Frame
+	focusedFrame *Frame
+}
+
+func newBackground(scene *ge.Scene, pos ge.Pos, width, height, borderWidth float64, frame, focusedFrame *Frame) *background {
+	bg := &background{
+		frame:        frame,
+		focusedFrame: focusedFrame,
+	}
+
+	if frame == nil {
+		bg.rect = ge.NewRect(scene.Context(), width, height)
+		bg.rect.OutlineWidth = borderWidth
+		bg.rect.Pos = pos
+		bg.rect.Centered = false
+		scene.AddGraphics(bg.rect)
+		return bg
+	}
+
+	bg.slice = ge.NewNineSlice(scene.Context(), width, height)
+	bg.slice.Pos = pos
+	bg.slice.Centered = false
+	bg.setFrame(frame)
+	scene.AddGraphics(bg.slice)
+	return bg
+}
+
+func (bg *background) setFrame(f *Frame) {
+	bg.slice.SetImage(f.Texture, f.Insets)
+	bg.slice.EdgeMode = f.EdgeMode
+	bg.slice.CenterMode = f.CenterMode
+}
+
+// SetColors updates the background colors.
+// The textured frame uses the fill color as its color scale.
+func (bg *background) SetColors(fill, border ge.ColorScale) {
+	if bg.slice != nil {
+		bg.slice.SetColorScale(fill)
+		return
+	}
+	bg.rect.FillColorScale = fill
+	bg.rect.OutlineColorScale = border
+}
+
+func (bg *background) SetFocused(focused bool) {
+	if bg.slice == nil || bg.focusedFrame == nil {
+		return
+	}
+	if focused {
+		bg.setFrame(bg.focusedFrame)
+	} else {
+		bg.setFrame(bg.frame)
+	}
+}
+
+// SetRect changes the background location and size.
+func (bg *background) SetRect(pos ge.Pos, size gmath.Vec) {
+	if bg.slice != nil {
+		bg.slice.Pos = pos
+		bg.slice.Width = size.X
+		bg.slice.Height = size.Y
+		return
+	}
+	bg.rect.Pos = pos
+	bg.rect.Width = size.X
+	bg.rect.Height = size.Y
+}
+
+func (bg *background) SetVisible(visible bool) {
+	if bg.slice != nil {
+		bg.slice.Visible = visible
+		return
+	}
+	bg.rect.Visible = visible
+}
+
+func (bg *background) IsDisposed() bool {
+	if bg.slice != nil {
+		return bg.slice.IsDisposed()
+	}
+	return bg.rect.IsDisposed()
+}
+
+func (bg *background) Dispose() {
+	if bg.slice != nil {
+		bg.slice.Dispose()
+		return
+	}
+	bg.rect.Dispose()
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge/input"
+)
+
+// Glyph is an input prompt icon, like a gamepad "A" button image.
+type Glyph struct {
+	Image resource.ImageID
+
+	// Frame is an atlas frame index.
+	// The frame size is defined by the image DefaultFrameWidth and DefaultFrameHeight.
+	// For the non-atlas images, it should be 0.
+	Frame int
+}
+
+// GlyphRegistry maps the input keys to their glyphs.
+//
+// The gamepad keys can have a different glyph for every gamepad style:
+// the same KeyGamepadA is a "A" for the Xbox controllers and a cross for the PlayStation ones.
+type GlyphRegistry struct {
+	glyphs map[glyphKey]Glyph
+}
+
+type glyphKey struct {
+	key   input.Key
+	style input.GamepadStyle
+}
+
+func NewGlyphRegistry() *GlyphRegistry {
+	return &GlyphRegistry{glyphs: make(map[glyphKey]Glyph)}
+}
+
+// Add registers a glyph for the key.
+// For the gamepad keys, this glyph is used for the styles
+// that don't have their own glyph registered, see AddStyled.
+func (r *GlyphRegistry) Add(k input.Key, g Glyph) {
+	r.AddStyled(input.GamepadStyleGeneric, k, g)
+}
+
+// AddStyled registers a glyph for the key that is used with the specified gamepad style.
+func (r *GlyphRegistry) AddStyled(style input.GamepadStyle, k input.Key, g Glyph) {
+	r.glyphs[glyphKey{key: k, style: style}] = g
+}
+
+// Find returns the glyph for the key.
+// If there is no glyph for the given style, a generic glyph is returned.
+func (r *GlyphRegistry) Find(k input.Key, style input.GamepadStyle) (Glyph, bool) {
+	if g, ok := r.glyphs[glyphKey{key: k, style: style}]; ok {
+		return g, true
+	}
+	g, ok := r.glyphs[glyphKey{key: k, style: input.GamepadStyleGeneric}]
+	return g, ok
+}
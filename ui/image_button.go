@@ -29,8 +29,9 @@ type ImageButton struct {
 	style              ImageButtonStyle
 
 	sprite *ge.Sprite
-	rect   *ge.Rect
+	bg     *background
 
+	size gmath.Vec
 	geom gmath.Rect
 
 	root *Root
@@ -49,6 +50,15 @@ type ImageButtonStyle struct {
 	FocusedBorderColor     ge.ColorScale
 	FocusedBackgroundColor ge.ColorScale
 	FocusedImageColor      ge.ColorScale
+
+	// Frame replaces the rect background with a textured frame.
+	// The background colors are used as the frame color scale then,
+	// the border settings are ignored.
+	Frame *Frame
+
+	// FocusedFrame is an optional frame that is used instead of the Frame
+	// while the button is focused.
+	FocusedFrame *Frame
 }
 
 func DefaultImageButtonStyle() ImageButtonStyle {
@@ -75,27 +85,24 @@ func (style ImageButtonStyle) Resized(w, h float64) ImageButtonStyle {
 
 func (b *ImageButton) SetImage(image Image) {
 	b.sprite.SetImage(image.Resource)
-	dx := (b.style.Width - b.sprite.FrameWidth) / 2
-	dy := (b.style.Height - b.sprite.FrameHeight) / 2
-	b.sprite.Pos = b.Pos.WithOffset(dx, dy)
+	b.placeSprite()
 	b.sprite.FlipHorizontal = image.FlipHorizontal
 	b.sprite.FlipVertical = image.FlipVertical
 }
 
+func (b *ImageButton) placeSprite() {
+	dx := (b.size.X - b.sprite.FrameWidth) / 2
+	dy := (b.size.Y - b.sprite.FrameHeight) / 2
+	b.sprite.Pos = b.Pos.WithOffset(dx, dy)
+}
+
 func (b *ImageButton) Init(scene *ge.Scene) {
-	pos := b.Pos.Resolve()
-	b.geom = gmath.Rect{
-		Min: pos,
-		Max: pos.Add(gmath.Vec{X: b.style.Width, Y: b.style.Height}),
+	if b.size.IsZero() {
+		b.size = b.preferredSize()
 	}
+	b.geom = widgetRect(b.Pos, b.size)
 
-	b.rect = ge.NewRect(b.root.ctx, b.style.Width, b.style.Height)
-	b.rect.FillColorScale = b.style.BackgroundColor
-	b.rect.OutlineColorScale = b.style.BorderColor
-	b.rect.OutlineWidth = b.style.BorderWidth
-	b.rect.Pos = b.Pos
-	b.rect.Centered = false
-	scene.AddGraphics(b.rect)
+	b.bg = newBackground(scene, b.Pos, b.size.X, b.size.Y, b.style.BorderWidth, b.style.Frame, b.style.FocusedFrame)
 
 	b.sprite = ge.NewSprite(scene.Context())
 	b.sprite.Centered = false
@@ -103,6 +110,20 @@ func (b *ImageButton) Init(scene *ge.Scene) {
 	scene.AddGraphics(b.sprite)
 }
 
+func (b *ImageButton) preferredSize() gmath.Vec {
+	return gmath.Vec{X: b.style.Width, Y: b.style.Height}
+}
+
+func (b *ImageButton) setLayout(pos ge.Pos, size gmath.Vec) {
+	b.Pos = pos
+	b.size = size
+	if b.sprite == nil {
+		return // Will be applied during the Init
+	}
+	b.bg.SetRect(pos, size)
+	b.placeSprite()
+}
+
 func (b *ImageButton) prevInput() inputElement     { return b.PrevInput }
 func (b *ImageButton) nextInput() inputElement     { return b.NextInput }
 func (b *ImageButton) setPrevInput(e inputElement) { b.PrevInput = e }
@@ -113,18 +134,18 @@ func (b *ImageButton) IsFocused() bool       { return b.root.focused == b }
 func (b *ImageButton) SetFocus(focused bool) { b.root.setFocus(b, focused) }
 
 func (b *ImageButton) onFocusChanged(focused bool) {
+	b.bg.SetFocused(focused)
 	if focused {
 		b.sprite.SetColorScale(b.style.FocusedImageColor)
-		b.rect.FillColorScale = b.style.FocusedBackgroundColor
-		b.rect.OutlineColorScale = b.style.FocusedBorderColor
+		b.bg.SetColors(b.style.FocusedBackgroundColor, b.style.FocusedBorderColor)
 	} else {
 		b.sprite.SetColorScale(b.style.ImageColor)
-		b.rect.FillColorScale = b.style.BackgroundColor
-		b.rect.OutlineColorScale = b.style.BorderColor
+		b.bg.SetColors(b.style.BackgroundColor, b.style.BorderColor)
 	}
 }
 
 func (b *ImageButton) Update(delta float64) {
+	b.geom = widgetRect(b.Pos, b.size)
 	if !b.disabled {
 		if b.geom.Contains(b.root.input.CursorPos()) {
 			b.root.setFocus(b, true)
@@ -135,19 +156,19 @@ func (b *ImageButton) Update(delta float64) {
 	}
 
 	b.sprite.Visible = b.Visible
-	b.rect.Visible = b.Visible
+	b.bg.SetVisible(b.Visible)
 
 	b.checkKeyboardInput = b.IsFocused()
 }
 
 func (b *ImageButton) IsDisposed() bool {
-	return b.rect.IsDisposed()
+	return b.bg.IsDisposed()
 }
 
 func (b *ImageButton) Dispose() {
 	b.eventDisposed.Emit(b)
 	b.sprite.Dispose()
-	b.rect.Dispose()
+	b.bg.Dispose()
 }
 
 func (b *ImageButton) Activate() {
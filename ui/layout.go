@@ -0,0 +1,519 @@
+package ui
+
+import (
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/gesignal"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+)
+
+// Widget is an element that can be placed inside a layout container.
+//
+// All ui elements, including the containers, are widgets.
+type Widget interface {
+	uiElement
+
+	preferredSize() gmath.Vec
+	setLayout(pos ge.Pos, size gmath.Vec)
+}
+
+// Anchor binds the top-level container to the window area.
+type Anchor uint8
+
+const (
+	// AnchorNone makes the container use its Pos.
+	AnchorNone Anchor = iota
+
+	AnchorTopLeft
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+
+	// AnchorFill stretches the container over the entire window.
+	AnchorFill
+)
+
+// Align describes how a child is placed inside its layout cell.
+type Align uint8
+
+const (
+	AlignStart Align = iota
+	AlignCenter
+	AlignEnd
+
+	// AlignStretch resizes the child to fill the layout cell.
+	AlignStretch
+)
+
+// Insets describe the space around the element.
+type Insets struct {
+	Top    float64
+	Right  float64
+	Bottom float64
+	Left   float64
+}
+
+// UniformInsets returns insets that have the same value on every side.
+func UniformInsets(v float64) Insets {
+	return Insets{Top: v, Right: v, Bottom: v, Left: v}
+}
+
+func (in Insets) size() gmath.Vec {
+	return gmath.Vec{X: in.Left + in.Right, Y: in.Top + in.Bottom}
+}
+
+type ContainerStyle struct {
+	// MinWidth and MinHeight are the container size lower bounds.
+	// By default, the container is as big as its contents.
+	MinWidth  float64
+	MinHeight float64
+
+	Padding Insets
+
+	// Spacing is a distance between the neighbouring children.
+	Spacing float64
+
+	// Align is applied to the children that are smaller than their layout cells.
+	Align Align
+}
+
+// LayoutOptions are the per-child container settings.
+type LayoutOptions struct {
+	// Stretch is a share of the container free space this child gets.
+	// For the boxes it's applied to the main axis (height for VBox, width for HBox),
+	// for the grids it's applied to both the column and the row of the child.
+	// The stretched children fill their layout cells.
+	//
+	// A zero value means that the child keeps its preferred size.
+	Stretch float64
+
+	Margin Insets
+}
+
+func DefaultContainerStyle() ContainerStyle {
+	return ContainerStyle{
+		Spacing: 8,
+		Align:   AlignCenter,
+	}
+}
+
+type containerKind uint8
+
+const (
+	containerVBox containerKind = iota
+	containerHBox
+	containerGrid
+)
+
+// Container positions its children automatically.
+//
+// A container is either a VBox (a column of children), a HBox (a row of children)
+// or a Grid with a fixed number of columns.
+// Containers can be nested: a container is a Widget itself.
+//
+// The layout is recomputed when the children are added or removed,
+// when their preferred size changes and when the window is resized.
+// The children Pos fields are assigned by the container;
+// add the children to the scene as usual.
+//
+// Disposing the container disposes all of its children.
+type Container struct {
+	// Pos is a top-level container location.
+	// It's not used for the anchored and nested containers.
+	Pos ge.Pos
+
+	// Anchor binds the top-level container to the window area.
+	// The nested containers ignore it.
+	Anchor Anchor
+
+	// Margin is a distance from the window edges for the anchored containers.
+	Margin Insets
+
+	eventDisposed gesignal.Event[*Container]
+
+	kind    containerKind
+	columns int
+	style   ContainerStyle
+
+	items []*layoutItem
+
+	// These fields are set by the parent container.
+	nested       bool
+	assignedSize gmath.Vec
+
+	// The last layout parameters; a change causes a re-layout.
+	rect       gmath.Rect
+	windowRect gmath.Rect
+
+	// This is a scratch slice.
+	tracks []layoutTrack
+
+	initialized bool
+	disposed    bool
+
+	root *Root
+}
+
+type layoutItem struct {
+	widget Widget
+	opts   LayoutOptions
+	pos    gmath.Vec
+	size   gmath.Vec
+}
+
+type layoutTrack struct {
+	pos    float64
+	size   float64
+	weight float64
+}
+
+// NewVBox creates a container that stacks the children vertically.
+func (r *Root) NewVBox(style ContainerStyle) *Container {
+	return r.newContainer(containerVBox, 1, style)
+}
+
+// NewHBox creates a container that places the children in a row.
+func (r *Root) NewHBox(style ContainerStyle) *Container {
+	return r.newContainer(containerHBox, 0, style)
+}
+
+// NewGrid creates a container that places the children into a table,
+// filling it row by row.
+func (r *Root) NewGrid(columns int, style ContainerStyle) *Container {
+	if columns <= 0 {
+		panic("grid columns number should be positive")
+	}
+	return r.newContainer(containerGrid, columns, style)
+}
+
+func (r *Root) newContainer(kind containerKind, columns int, style ContainerStyle) *Container {
+	e := &Container{
+		kind:    kind,
+		columns: columns,
+		style:   style,
+		root:    r,
+	}
+	e.eventDisposed.Connect(r, func(c *Container) {
+		if r.disposed {
+			return
+		}
+		r.elems = xslices.RemoveIf(r.elems, func(e uiElement) bool {
+			return c == e
+		})
+	})
+	r.elems = append(r.elems, e)
+	return e
+}
+
+// AddChild appends the widget to the container with default layout options.
+func (c *Container) AddChild(w Widget) {
+	c.AddChildWithOptions(w, LayoutOptions{})
+}
+
+// AddChildWithOptions appends the widget to the container.
+func (c *Container) AddChildWithOptions(w Widget, opts LayoutOptions) {
+	if w == Widget(c) {
+		panic("can't add a container to itself")
+	}
+	if other, ok := w.(*Container); ok {
+		other.nested = true
+	}
+	c.items = append(c.items, &layoutItem{widget: w, opts: opts})
+	if c.initialized {
+		c.Relayout()
+	}
+}
+
+// RemoveChild removes the widget from the container.
+// The widget is not disposed.
+func (c *Container) RemoveChild(w Widget) {
+	c.items = xslices.RemoveIf(c.items, func(item *layoutItem) bool {
+		return item.widget == w
+	})
+	if other, ok := w.(*Container); ok {
+		other.nested = false
+	}
+	if c.initialized {
+		c.Relayout()
+	}
+}
+
+// NumChildren reports the number of the container children.
+func (c *Container) NumChildren() int { return len(c.items) }
+
+// Rect returns the container area computed during the last layout.
+func (c *Container) Rect() gmath.Rect { return c.rect }
+
+func (c *Container) Init(scene *ge.Scene) {
+	c.initialized = true
+	c.Relayout()
+}
+
+func (c *Container) IsDisposed() bool { return c.disposed }
+
+func (c *Container) Dispose() {
+	c.eventDisposed.Emit(c)
+	c.disposed = true
+	for _, item := range c.items {
+		if !item.widget.IsDisposed() {
+			item.widget.Dispose()
+		}
+	}
+}
+
+func (c *Container) Update(delta float64) {
+	if c.needsRelayout() {
+		c.Relayout()
+	}
+}
+
+// Relayout recomputes the children positions and sizes.
+//
+// It's called automatically when the container detects a layout change,
+// so there is rarely a need to call it directly.
+func (c *Container) Relayout() {
+	c.removeDisposed()
+	for _, item := range c.items {
+		item.size = item.widget.preferredSize()
+	}
+
+	rect := c.layoutRect()
+	c.rect = rect
+	if !c.nested && c.Anchor != AnchorNone {
+		c.windowRect = c.root.ctx.WindowRect()
+	}
+	if len(c.items) == 0 {
+		return
+	}
+
+	inner := gmath.Rect{
+		Min: rect.Min.Add(gmath.Vec{X: c.style.Padding.Left, Y: c.style.Padding.Top}),
+		Max: rect.Max.Sub(gmath.Vec{X: c.style.Padding.Right, Y: c.style.Padding.Bottom}),
+	}
+
+	numColumns, numRows := c.gridSize()
+	c.tracks = c.tracks[:0]
+	for i := 0; i < numColumns+numRows; i++ {
+		c.tracks = append(c.tracks, layoutTrack{})
+	}
+	columns := c.tracks[:numColumns]
+	rows := c.tracks[numColumns:]
+	c.measureTracks(columns, rows)
+	switch c.kind {
+	case containerVBox:
+		// The only column takes the entire container width.
+		columns[0].weight = 1
+	case containerHBox:
+		rows[0].weight = 1
+	}
+	distributeTracks(columns, inner.Min.X, inner.Width(), c.style.Spacing)
+	distributeTracks(rows, inner.Min.Y, inner.Height(), c.style.Spacing)
+
+	for i, item := range c.items {
+		col, row := c.itemCell(i)
+		stretchX, stretchY := c.itemStretch(item)
+		m := item.opts.Margin
+		cellPos := gmath.Vec{X: columns[col].pos + m.Left, Y: rows[row].pos + m.Top}
+		cellSize := gmath.Vec{X: columns[col].size - m.Left - m.Right, Y: rows[row].size - m.Top - m.Bottom}
+		pos := gmath.Vec{}
+		size := gmath.Vec{}
+		pos.X, size.X = alignInCell(c.style.Align, stretchX != 0, cellPos.X, cellSize.X, item.size.X)
+		pos.Y, size.Y = alignInCell(c.style.Align, stretchY != 0, cellPos.Y, cellSize.Y, item.size.Y)
+		item.pos = pos
+		item.widget.setLayout(ge.Pos{Base: &item.pos}, size)
+	}
+}
+
+func (c *Container) preferredSize() gmath.Vec {
+	numColumns, numRows := c.gridSize()
+	c.tracks = c.tracks[:0]
+	for i := 0; i < numColumns+numRows; i++ {
+		c.tracks = append(c.tracks, layoutTrack{})
+	}
+	columns := c.tracks[:numColumns]
+	rows := c.tracks[numColumns:]
+	c.measureTracks(columns, rows)
+
+	size := c.style.Padding.size()
+	size.X += tracksSize(columns, c.style.Spacing)
+	size.Y += tracksSize(rows, c.style.Spacing)
+	size.X = gmath.ClampMin(size.X, c.style.MinWidth)
+	size.Y = gmath.ClampMin(size.Y, c.style.MinHeight)
+	return size
+}
+
+func (c *Container) setLayout(pos ge.Pos, size gmath.Vec) {
+	c.Pos = pos
+	c.assignedSize = size
+	c.nested = true
+	c.Relayout()
+}
+
+func (c *Container) layoutRect() gmath.Rect {
+	size := c.preferredSize()
+
+	if c.nested || c.Anchor == AnchorNone {
+		if c.nested {
+			size.X = gmath.ClampMin(size.X, c.assignedSize.X)
+			size.Y = gmath.ClampMin(size.Y, c.assignedSize.Y)
+		}
+		pos := c.Pos.Resolve()
+		return gmath.Rect{Min: pos, Max: pos.Add(size)}
+	}
+
+	window := c.root.ctx.WindowRect()
+	area := gmath.Rect{
+		Min: window.Min.Add(gmath.Vec{X: c.Margin.Left, Y: c.Margin.Top}),
+		Max: window.Max.Sub(gmath.Vec{X: c.Margin.Right, Y: c.Margin.Bottom}),
+	}
+	if c.Anchor == AnchorFill {
+		size.X = gmath.ClampMin(size.X, area.Width())
+		size.Y = gmath.ClampMin(size.Y, area.Height())
+		return gmath.Rect{Min: area.Min, Max: area.Min.Add(size)}
+	}
+
+	var alignX, alignY Align
+	switch c.Anchor {
+	case AnchorTopLeft:
+		alignX, alignY = AlignStart, AlignStart
+	case AnchorTop:
+		alignX, alignY = AlignCenter, AlignStart
+	case AnchorTopRight:
+		alignX, alignY = AlignEnd, AlignStart
+	case AnchorLeft:
+		alignX, alignY = AlignStart, AlignCenter
+	case AnchorCenter:
+		alignX, alignY = AlignCenter, AlignCenter
+	case AnchorRight:
+		alignX, alignY = AlignEnd, AlignCenter
+	case AnchorBottomLeft:
+		alignX, alignY = AlignStart, AlignEnd
+	case AnchorBottom:
+		alignX, alignY = AlignCenter, AlignEnd
+	case AnchorBottomRight:
+		alignX, alignY = AlignEnd, AlignEnd
+	}
+	var pos gmath.Vec
+	pos.X, _ = alignInCell(alignX, false, area.Min.X, area.Width(), size.X)
+	pos.Y, _ = alignInCell(alignY, false, area.Min.Y, area.Height(), size.Y)
+	return gmath.Rect{Min: pos, Max: pos.Add(size)}
+}
+
+func (c *Container) needsRelayout() bool {
+	for _, item := range c.items {
+		if item.widget.IsDisposed() || item.widget.preferredSize() != item.size {
+			return true
+		}
+	}
+	if c.nested {
+		// The parent container handles the rest.
+		return false
+	}
+	if c.Anchor == AnchorNone {
+		return c.Pos.Resolve() != c.rect.Min
+	}
+	return c.root.ctx.WindowRect() != c.windowRect
+}
+
+func (c *Container) removeDisposed() {
+	c.items = xslices.RemoveIf(c.items, func(item *layoutItem) bool {
+		return item.widget.IsDisposed()
+	})
+}
+
+func (c *Container) gridSize() (columns, rows int) {
+	n := len(c.items)
+	switch c.kind {
+	case containerVBox:
+		return 1, n
+	case containerHBox:
+		return n, 1
+	default:
+		return c.columns, (n + c.columns - 1) / c.columns
+	}
+}
+
+func (c *Container) itemCell(i int) (col, row int) {
+	switch c.kind {
+	case containerVBox:
+		return 0, i
+	case containerHBox:
+		return i, 0
+	default:
+		return i % c.columns, i / c.columns
+	}
+}
+
+func (c *Container) itemStretch(item *layoutItem) (x, y float64) {
+	switch c.kind {
+	case containerVBox:
+		return 0, item.opts.Stretch
+	case containerHBox:
+		return item.opts.Stretch, 0
+	default:
+		return item.opts.Stretch, item.opts.Stretch
+	}
+}
+
+func (c *Container) measureTracks(columns, rows []layoutTrack) {
+	for i, item := range c.items {
+		col, row := c.itemCell(i)
+		stretchX, stretchY := c.itemStretch(item)
+		// Note that item.size can't be used here: it's a snapshot
+		// from the last layout which is used to detect the changes.
+		size := item.widget.preferredSize().Add(item.opts.Margin.size())
+		columns[col].size = gmath.ClampMin(columns[col].size, size.X)
+		columns[col].weight = gmath.ClampMin(columns[col].weight, stretchX)
+		rows[row].size = gmath.ClampMin(rows[row].size, size.Y)
+		rows[row].weight = gmath.ClampMin(rows[row].weight, stretchY)
+	}
+}
+
+func tracksSize(tracks []layoutTrack, spacing float64) float64 {
+	if len(tracks) == 0 {
+		return 0
+	}
+	total := spacing * float64(len(tracks)-1)
+	for _, t := range tracks {
+		total += t.size
+	}
+	return total
+}
+
+// distributeTracks assigns the tracks positions and gives
+// the free space to the tracks according to their weights.
+func distributeTracks(tracks []layoutTrack, start, available, spacing float64) {
+	extra := available - tracksSize(tracks, spacing)
+	totalWeight := 0.0
+	for _, t := range tracks {
+		totalWeight += t.weight
+	}
+	pos := start
+	for i := range tracks {
+		t := &tracks[i]
+		if extra > 0 && totalWeight > 0 {
+			t.size += extra * (t.weight / totalWeight)
+		}
+		t.pos = pos
+		pos += t.size + spacing
+	}
+}
+
+func alignInCell(align Align, stretch bool, cellPos, cellSize, size float64) (float64, float64) {
+	if stretch || align == AlignStretch {
+		return cellPos, cellSize
+	}
+	switch align {
+	case AlignCenter:
+		return cellPos + (cellSize-size)/2, size
+	case AlignEnd:
+		return cellPos + cellSize - size, size
+	default:
+		return cellPos, size
+	}
+}
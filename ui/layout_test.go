@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+)
+
+type testWidget struct {
+	pref     gmath.Vec
+	pos      ge.Pos
+	size     gmath.Vec
+	disposed bool
+}
+
+func (w *testWidget) Dispose()                 { w.disposed = true }
+func (w *testWidget) IsDisposed() bool         { return w.disposed }
+func (w *testWidget) preferredSize() gmath.Vec { return w.pref }
+
+func (w *testWidget) setLayout(pos ge.Pos, size gmath.Vec) {
+	w.pos = pos
+	w.size = size
+}
+
+func (w *testWidget) rect() gmath.Rect {
+	pos := w.pos.Resolve()
+	return gmath.Rect{Min: pos, Max: pos.Add(w.size)}
+}
+
+func newTestWidget(w, h float64) *testWidget {
+	return &testWidget{pref: gmath.Vec{X: w, Y: h}}
+}
+
+func testRect(x, y, w, h float64) gmath.Rect {
+	return gmath.Rect{Min: gmath.Vec{X: x, Y: y}, Max: gmath.Vec{X: x + w, Y: y + h}}
+}
+
+func TestDistributeTracks(t *testing.T) {
+	tests := []struct {
+		name      string
+		tracks    []layoutTrack
+		start     float64
+		available float64
+		spacing   float64
+		want      []layoutTrack
+	}{
+		{
+			name:      "no weights",
+			tracks:    []layoutTrack{{size: 10}, {size: 20}},
+			start:     5,
+			available: 100,
+			spacing:   4,
+			want:      []layoutTrack{{pos: 5, size: 10}, {pos: 19, size: 20}},
+		},
+		{
+			name:      "weights",
+			tracks:    []layoutTrack{{size: 10, weight: 1}, {size: 20, weight: 3}},
+			available: 70,
+			want:      []layoutTrack{{pos: 0, size: 20, weight: 1}, {pos: 20, size: 50, weight: 3}},
+		},
+		{
+			name:      "weights with spacing",
+			tracks:    []layoutTrack{{size: 10}, {size: 10, weight: 1}},
+			available: 50,
+			spacing:   10,
+			want:      []layoutTrack{{pos: 0, size: 10}, {pos: 20, size: 30, weight: 1}},
+		},
+		{
+			name:      "not enough space",
+			tracks:    []layoutTrack{{size: 30, weight: 1}, {size: 30, weight: 1}},
+			available: 40,
+			spacing:   2,
+			want:      []layoutTrack{{pos: 0, size: 30, weight: 1}, {pos: 32, size: 30, weight: 1}},
+		},
+	}
+
+	for _, test := range tests {
+		tracks := append([]layoutTrack(nil), test.tracks...)
+		distributeTracks(tracks, test.start, test.available, test.spacing)
+		for i := range tracks {
+			if tracks[i] != test.want[i] {
+				t.Fatalf("%s: track %d mismatch:\nhave: %+v\nwant: %+v", test.name, i, tracks[i], test.want[i])
+			}
+		}
+	}
+}
+
+func TestAlignInCell(t *testing.T) {
+	tests := []struct {
+		align   Align
+		stretch bool
+		pos     float64
+		size    float64
+	}{
+		{AlignStart, false, 10, 30},
+		{AlignCenter, false, 45, 30},
+		{AlignEnd, false, 80, 30},
+		{AlignStretch, false, 10, 100},
+		{AlignEnd, true, 10, 100},
+	}
+
+	for _, test := range tests {
+		pos, size := alignInCell(test.align, test.stretch, 10, 100, 30)
+		if pos != test.pos || size != test.size {
+			t.Fatalf("align=%d stretch=%v:\nhave: %v, %v\nwant: %v, %v",
+				test.align, test.stretch, pos, size, test.pos, test.size)
+		}
+	}
+}
+
+func TestContainerPreferredSize(t *testing.T) {
+	r := NewRoot(&ge.Context{}, nil)
+	style := ContainerStyle{
+		Padding: Insets{Top: 1, Right: 2, Bottom: 3, Left: 4},
+		Spacing: 5,
+	}
+
+	addChildren := func(c *Container) *Container {
+		c.AddChild(newTestWidget(100, 20))
+		c.AddChildWithOptions(newTestWidget(50, 30), LayoutOptions{Margin: UniformInsets(2)})
+		return c
+	}
+
+	minStyle := style
+	minStyle.MinWidth = 200
+	minStyle.MinHeight = 10
+
+	tests := []struct {
+		name string
+		c    *Container
+		want gmath.Vec
+	}{
+		{"empty", r.NewVBox(style), gmath.Vec{X: 6, Y: 4}},
+		{"vbox", addChildren(r.NewVBox(style)), gmath.Vec{X: 106, Y: 63}},
+		{"hbox", addChildren(r.NewHBox(style)), gmath.Vec{X: 165, Y: 38}},
+		{"min size", addChildren(r.NewVBox(minStyle)), gmath.Vec{X: 200, Y: 63}},
+	}
+
+	grid := r.NewGrid(2, ContainerStyle{Spacing: 1})
+	grid.AddChild(newTestWidget(10, 10))
+	grid.AddChild(newTestWidget(20, 5))
+	grid.AddChild(newTestWidget(5, 15))
+	tests = append(tests, struct {
+		name string
+		c    *Container
+		want gmath.Vec
+	}{"grid", grid, gmath.Vec{X: 31, Y: 26}})
+
+	for _, test := range tests {
+		if have := test.c.preferredSize(); have != test.want {
+			t.Fatalf("%s: size mismatch:\nhave: %v\nwant: %v", test.name, have, test.want)
+		}
+	}
+}
+
+func TestContainerAnchor(t *testing.T) {
+	tests := []struct {
+		anchor Anchor
+		margin Insets
+		want   gmath.Rect
+	}{
+		{AnchorTopLeft, Insets{}, testRect(0, 0, 210, 70)},
+		{AnchorCenter, Insets{}, testRect(295, 265, 210, 70)},
+		{AnchorBottomRight, UniformInsets(20), testRect(570, 510, 210, 70)},
+		{AnchorTop, Insets{Top: 10}, testRect(295, 10, 210, 70)},
+		{AnchorFill, UniformInsets(10), testRect(10, 10, 780, 580)},
+	}
+
+	for _, test := range tests {
+		ctx := &ge.Context{WindowWidth: 800, WindowHeight: 600}
+		r := NewRoot(ctx, nil)
+		c := r.NewVBox(ContainerStyle{Spacing: 10, Padding: UniformInsets(5)})
+		c.Anchor = test.anchor
+		c.Margin = test.margin
+		c.AddChild(newTestWidget(100, 20))
+		c.AddChild(newTestWidget(200, 30))
+		c.Init(nil)
+		if c.Rect() != test.want {
+			t.Fatalf("anchor=%d margin=%+v:\nhave: %v\nwant: %v", test.anchor, test.margin, c.Rect(), test.want)
+		}
+	}
+}
+
+func TestContainerLayout(t *testing.T) {
+	ctx := &ge.Context{WindowWidth: 800, WindowHeight: 600}
+	r := NewRoot(ctx, nil)
+
+	c := r.NewVBox(ContainerStyle{Spacing: 10, Padding: UniformInsets(5), Align: AlignCenter})
+	c.Anchor = AnchorCenter
+	a := newTestWidget(100, 20)
+	b := newTestWidget(200, 30)
+	c.AddChild(a)
+	c.AddChild(b)
+	c.Init(nil)
+
+	checkRect := func(step, name string, have, want gmath.Rect) {
+		t.Helper()
+		if have != want {
+			t.Fatalf("%s: %s rect mismatch:\nhave: %v\nwant: %v", step, name, have, want)
+		}
+	}
+
+	checkRect("init", "a", a.rect(), testRect(350, 270, 100, 20))
+	checkRect("init", "b", b.rect(), testRect(300, 300, 200, 30))
+
+	ctx.WindowWidth = 1000
+	c.Update(0)
+	checkRect("resize", "container", c.Rect(), testRect(395, 265, 210, 70))
+	checkRect("resize", "a", a.rect(), testRect(450, 270, 100, 20))
+
+	b.pref.Y = 50
+	c.Update(0)
+	checkRect("grow", "container", c.Rect(), testRect(395, 255, 210, 90))
+	checkRect("grow", "b", b.rect(), testRect(400, 290, 200, 50))
+
+	a.Dispose()
+	c.Update(0)
+	if c.NumChildren() != 1 {
+		t.Fatalf("disposed child is not removed: %d children", c.NumChildren())
+	}
+	checkRect("dispose", "b", b.rect(), testRect(400, 275, 200, 50))
+}
+
+func TestContainerStretch(t *testing.T) {
+	ctx := &ge.Context{WindowWidth: 800, WindowHeight: 600}
+	r := NewRoot(ctx, nil)
+
+	c := r.NewVBox(ContainerStyle{Align: AlignStart})
+	c.Anchor = AnchorFill
+	a := newTestWidget(100, 20)
+	b := newTestWidget(100, 20)
+	c.AddChild(a)
+	c.AddChildWithOptions(b, LayoutOptions{Stretch: 1, Margin: Insets{Top: 5}})
+
+	h := r.NewHBox(ContainerStyle{Spacing: 4, Align: AlignStart, MinWidth: 200})
+	d := newTestWidget(50, 10)
+	e := newTestWidget(50, 10)
+	h.AddChild(d)
+	h.AddChildWithOptions(e, LayoutOptions{Stretch: 1})
+	c.AddChild(h)
+	c.Init(nil)
+
+	checkRect := func(name string, have, want gmath.Rect) {
+		t.Helper()
+		if have != want {
+			t.Fatalf("%s rect mismatch:\nhave: %v\nwant: %v", name, have, want)
+		}
+	}
+
+	checkRect("container", c.Rect(), testRect(0, 0, 800, 600))
+	checkRect("a", a.rect(), testRect(0, 0, 100, 20))
+	checkRect("b", b.rect(), testRect(0, 25, 100, 565))
+	checkRect("hbox", h.Rect(), testRect(0, 590, 200, 10))
+	checkRect("d", d.rect(), testRect(0, 590, 50, 10))
+	checkRect("e", e.rect(), testRect(54, 590, 146, 10))
+}
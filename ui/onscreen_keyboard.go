@@ -0,0 +1,487 @@
+package ui
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/gesignal"
+	"github.com/quasilyte/ge/input"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+)
+
+// OnScreenKeyboard is a virtual keyboard for the gamepad and touch users.
+//
+// It's opened by the TextInput that has this keyboard attached
+// (see TextInput.SetOnScreenKeyboard) and it's hidden otherwise.
+// While it's open, it takes the focus:
+// the Root PrevInputAction and NextInputAction select a row,
+// the DecreaseAction and IncreaseAction select a key inside the row.
+// A click or a tap presses the key under the cursor.
+//
+// The Done key submits the text and closes the keyboard.
+// The Cancel key closes the keyboard without submitting the text.
+type OnScreenKeyboard struct {
+	Pos ge.Pos
+
+	PrevInput inputElement
+	NextInput inputElement
+
+	eventDisposed gesignal.Event[*OnScreenKeyboard]
+
+	checkKeyboardInput bool
+	style              OnScreenKeyboardStyle
+
+	target *TextInput
+	shift  bool
+
+	rows      [][]*keyboardKey
+	cursorRow int
+	cursorCol int
+
+	bg *background
+
+	size gmath.Vec
+	geom gmath.Rect
+
+	disposed bool
+
+	root *Root
+}
+
+type OnScreenKeyboardStyle struct {
+	KeyWidth  float64
+	KeyHeight float64
+
+	// Spacing is a distance between the neighbouring keys.
+	Spacing float64
+
+	Padding float64
+
+	BorderWidth float64
+
+	Font resource.FontID
+
+	// Rows describe the char keys layout, one string per row.
+	// The Shift, Space, Backspace, Cancel and Done keys are added as the last row.
+	Rows []string
+
+	ShiftText     string
+	SpaceText     string
+	BackspaceText string
+	CancelText    string
+	DoneText      string
+
+	BorderColor     ge.ColorScale
+	BackgroundColor ge.ColorScale
+
+	KeyColor        ge.ColorScale
+	FocusedKeyColor ge.ColorScale
+	ActiveKeyColor  ge.ColorScale
+
+	TextColor        ge.ColorScale
+	FocusedTextColor ge.ColorScale
+}
+
+func DefaultOnScreenKeyboardStyle() OnScreenKeyboardStyle {
+	return OnScreenKeyboardStyle{
+		KeyWidth:    40,
+		KeyHeight:   40,
+		Spacing:     4,
+		Padding:     8,
+		BorderWidth: 1,
+
+		Rows: []string{
+			"1234567890",
+			"qwertyuiop",
+			"asdfghjkl-",
+			"zxcvbnm.,_",
+		},
+
+		ShiftText:     "Shift",
+		SpaceText:     "Space",
+		BackspaceText: "Del",
+		CancelText:    "Esc",
+		DoneText:      "Done",
+
+		BorderColor:     grayColor,
+		BackgroundColor: withAlpha(darkGrayColor, 0.9),
+
+		KeyColor:        darkGrayColor,
+		FocusedKeyColor: grayColor,
+		ActiveKeyColor:  withAlpha(grayColor, 0.6),
+
+		TextColor:        grayColor,
+		FocusedTextColor: darkGrayColor,
+	}
+}
+
+type keyboardKeyKind uint8
+
+const (
+	keyboardKeyChar keyboardKeyKind = iota
+	keyboardKeyShift
+	keyboardKeySpace
+	keyboardKeyBackspace
+	keyboardKeyCancel
+	keyboardKeyDone
+)
+
+type keyboardKey struct {
+	kind keyboardKeyKind
+	char rune
+
+	// rect is a key area relative to the keyboard position.
+	rect gmath.Rect
+
+	bg    *ge.Rect
+	label *ge.Label
+}
+
+func (k *keyboardKey) center() float64 {
+	return (k.rect.Min.X + k.rect.Max.X) / 2
+}
+
+func (r *Root) NewOnScreenKeyboard(style OnScreenKeyboardStyle) *OnScreenKeyboard {
+	if len(style.Rows) == 0 {
+		panic("on-screen keyboard needs at least one row")
+	}
+	e := &OnScreenKeyboard{
+		style: style,
+		root:  r,
+	}
+	e.createKeys()
+	e.eventDisposed.Connect(r, func(k *OnScreenKeyboard) {
+		if r.disposed {
+			return
+		}
+		r.inputElems = xslices.RemoveIf(r.inputElems, func(e inputElement) bool {
+			return k == e
+		})
+	})
+	r.inputElems = append(r.inputElems, e)
+	return e
+}
+
+// IsOpen reports whether the keyboard is being used by some text input.
+func (k *OnScreenKeyboard) IsOpen() bool { return k.target != nil }
+
+func (k *OnScreenKeyboard) createKeys() {
+	numColumns := 0
+	for _, row := range k.style.Rows {
+		numColumns = gmath.ClampMin(numColumns, utf8.RuneCountInString(row))
+	}
+	rowWidth := float64(numColumns)*k.style.KeyWidth + float64(numColumns-1)*k.style.Spacing
+	pad := k.style.Padding
+
+	y := pad
+	for _, row := range k.style.Rows {
+		n := utf8.RuneCountInString(row)
+		width := float64(n)*k.style.KeyWidth + float64(n-1)*k.style.Spacing
+		// The shorter rows are centered.
+		x := pad + (rowWidth-width)/2
+		keys := make([]*keyboardKey, 0, n)
+		for _, ch := range row {
+			keys = append(keys, &keyboardKey{
+				kind: keyboardKeyChar,
+				char: ch,
+				rect: k.keyRect(x, y, k.style.KeyWidth),
+			})
+			x += k.style.KeyWidth + k.style.Spacing
+		}
+		k.rows = append(k.rows, keys)
+		y += k.style.KeyHeight + k.style.Spacing
+	}
+
+	// The special keys are sized in "units": Space=4, all others=2.
+	specialKeys := []struct {
+		kind  keyboardKeyKind
+		units float64
+	}{
+		{keyboardKeyShift, 2},
+		{keyboardKeySpace, 4},
+		{keyboardKeyBackspace, 2},
+		{keyboardKeyCancel, 2},
+		{keyboardKeyDone, 2},
+	}
+	totalUnits := 0.0
+	for _, special := range specialKeys {
+		totalUnits += special.units
+	}
+	unitWidth := (rowWidth - float64(len(specialKeys)-1)*k.style.Spacing) / totalUnits
+	x := pad
+	keys := make([]*keyboardKey, 0, len(specialKeys))
+	for _, special := range specialKeys {
+		width := unitWidth * special.units
+		keys = append(keys, &keyboardKey{
+			kind: special.kind,
+			rect: k.keyRect(x, y, width),
+		})
+		x += width + k.style.Spacing
+	}
+	k.rows = append(k.rows, keys)
+	y += k.style.KeyHeight
+
+	k.size = gmath.Vec{X: rowWidth + 2*pad, Y: y + pad}
+}
+
+func (k *OnScreenKeyboard) keyRect(x, y, width float64) gmath.Rect {
+	return gmath.Rect{
+		Min: gmath.Vec{X: x, Y: y},
+		Max: gmath.Vec{X: x + width, Y: y + k.style.KeyHeight},
+	}
+}
+
+func (k *OnScreenKeyboard) Init(scene *ge.Scene) {
+	k.geom = widgetRect(k.Pos, k.size)
+
+	k.bg = newBackground(scene, k.Pos, k.size.X, k.size.Y, k.style.BorderWidth, nil, nil)
+	k.bg.SetColors(k.style.BackgroundColor, k.style.BorderColor)
+
+	for _, row := range k.rows {
+		for _, key := range row {
+			key.bg = ge.NewRect(scene.Context(), key.rect.Width(), key.rect.Height())
+			key.bg.Centered = false
+			scene.AddGraphics(key.bg)
+
+			key.label = scene.NewLabel(k.style.Font)
+			key.label.Width = key.rect.Width()
+			key.label.Height = key.rect.Height()
+			key.label.AlignHorizontal = ge.AlignHorizontalCenter
+			key.label.AlignVertical = ge.AlignVerticalCenter
+			scene.AddGraphics(key.label)
+		}
+	}
+
+	k.placeKeys()
+	k.updateKeys()
+}
+
+func (k *OnScreenKeyboard) preferredSize() gmath.Vec { return k.size }
+
+// setLayout only moves the keyboard: its size is defined by the style.
+func (k *OnScreenKeyboard) setLayout(pos ge.Pos, size gmath.Vec) {
+	k.Pos = pos
+	if k.bg == nil {
+		return // Will be applied during the Init
+	}
+	k.bg.SetRect(pos, k.size)
+	k.placeKeys()
+}
+
+func (k *OnScreenKeyboard) placeKeys() {
+	for _, row := range k.rows {
+		for _, key := range row {
+			pos := k.Pos.WithOffset(key.rect.Min.X, key.rect.Min.Y)
+			key.bg.Pos = pos
+			key.label.Pos = pos
+		}
+	}
+}
+
+func (k *OnScreenKeyboard) prevInput() inputElement     { return k.PrevInput }
+func (k *OnScreenKeyboard) nextInput() inputElement     { return k.NextInput }
+func (k *OnScreenKeyboard) setPrevInput(e inputElement) { k.PrevInput = e }
+func (k *OnScreenKeyboard) setNextInput(e inputElement) { k.NextInput = e }
+
+// IsDisabled reports true for the closed keyboard,
+// so it's skipped during the focus navigation.
+func (k *OnScreenKeyboard) IsDisabled() bool      { return !k.IsOpen() }
+func (k *OnScreenKeyboard) IsFocused() bool       { return k.root.focused == k }
+func (k *OnScreenKeyboard) SetFocus(focused bool) { k.root.setFocus(k, focused) }
+
+func (k *OnScreenKeyboard) onFocusChanged(focused bool) {}
+
+func (k *OnScreenKeyboard) handleNavigation(next bool) bool {
+	if !k.IsOpen() {
+		return false
+	}
+	row := k.cursorRow - 1
+	if next {
+		row = k.cursorRow + 1
+	}
+	row = (row + len(k.rows)) % len(k.rows)
+	// Select the key that is closest to the current one horizontally.
+	x := k.rows[k.cursorRow][k.cursorCol].center()
+	bestDist := math.MaxFloat64
+	for col, key := range k.rows[row] {
+		if dist := math.Abs(key.center() - x); dist < bestDist {
+			bestDist = dist
+			k.cursorCol = col
+		}
+	}
+	k.cursorRow = row
+	return true
+}
+
+func (k *OnScreenKeyboard) open(t *TextInput) {
+	if k.target != nil && k.target != t {
+		k.target.StopEditing()
+	}
+	k.target = t
+	k.shift = false
+	k.SetFocus(true)
+}
+
+func (k *OnScreenKeyboard) close() {
+	t := k.target
+	k.target = nil
+	if t != nil && !k.root.disposed {
+		// Give the focus back to the text input.
+		k.root.setFocus(t, true)
+	}
+}
+
+func (k *OnScreenKeyboard) Update(delta float64) {
+	k.geom = widgetRect(k.Pos, k.size)
+	if k.IsOpen() {
+		cursorPos := k.root.input.CursorPos()
+		if row, col, ok := k.keyAt(cursorPos); ok && (row != k.cursorRow || col != k.cursorCol || !k.IsFocused()) {
+			k.cursorRow = row
+			k.cursorCol = col
+			k.SetFocus(true)
+		}
+		k.checkInput()
+	}
+	k.updateKeys()
+
+	k.checkKeyboardInput = k.IsFocused()
+}
+
+func (k *OnScreenKeyboard) IsDisposed() bool { return k.disposed }
+
+func (k *OnScreenKeyboard) Dispose() {
+	if k.target != nil {
+		k.target.StopEditing()
+	}
+	k.eventDisposed.Emit(k)
+	k.disposed = true
+	k.bg.Dispose()
+	for _, row := range k.rows {
+		for _, key := range row {
+			key.bg.Dispose()
+			key.label.Dispose()
+		}
+	}
+}
+
+func (k *OnScreenKeyboard) checkInput() {
+	if k.IsFocused() {
+		if k.root.actionIsJustPressed(k.root.DecreaseAction) {
+			n := len(k.rows[k.cursorRow])
+			k.cursorCol = (k.cursorCol - 1 + n) % n
+		}
+		if k.root.actionIsJustPressed(k.root.IncreaseAction) {
+			k.cursorCol = (k.cursorCol + 1) % len(k.rows[k.cursorRow])
+		}
+	}
+
+	if k.root.ActivationAction == actionUnset {
+		return
+	}
+	info, ok := k.root.input.JustPressedActionInfo(k.root.ActivationAction)
+	if !ok {
+		return
+	}
+	if !k.checkKeyboardInput && !info.IsTouchEvent() {
+		return
+	}
+	if !info.HasPos() {
+		k.press(k.rows[k.cursorRow][k.cursorCol])
+		return
+	}
+	if row, col, ok := k.keyAt(info.Pos); ok {
+		k.cursorRow = row
+		k.cursorCol = col
+		k.press(k.rows[row][col])
+	}
+}
+
+func (k *OnScreenKeyboard) keyAt(pos gmath.Vec) (int, int, bool) {
+	if !k.geom.Contains(pos) {
+		return 0, 0, false
+	}
+	local := pos.Sub(k.geom.Min)
+	for i, row := range k.rows {
+		for j, key := range row {
+			if key.rect.Contains(local) {
+				return i, j, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func (k *OnScreenKeyboard) press(key *keyboardKey) {
+	t := k.target
+	switch key.kind {
+	case keyboardKeyChar:
+		s := string(key.char)
+		if k.shift {
+			s = strings.ToUpper(s)
+			// Shift only affects the next char.
+			k.shift = false
+		}
+		t.typeText(s)
+	case keyboardKeyShift:
+		k.shift = !k.shift
+	case keyboardKeySpace:
+		t.typeText(" ")
+	case keyboardKeyBackspace:
+		t.typeEdit(input.TextEditBackspace)
+	case keyboardKeyCancel:
+		t.typeEdit(input.TextEditCancel)
+	case keyboardKeyDone:
+		t.typeEdit(input.TextEditSubmit)
+	}
+}
+
+func (k *OnScreenKeyboard) keyText(key *keyboardKey) string {
+	switch key.kind {
+	case keyboardKeyShift:
+		return k.style.ShiftText
+	case keyboardKeySpace:
+		return k.style.SpaceText
+	case keyboardKeyBackspace:
+		return k.style.BackspaceText
+	case keyboardKeyCancel:
+		return k.style.CancelText
+	case keyboardKeyDone:
+		return k.style.DoneText
+	default:
+		if k.shift {
+			return strings.ToUpper(string(key.char))
+		}
+		return string(key.char)
+	}
+}
+
+func (k *OnScreenKeyboard) updateKeys() {
+	visible := k.IsOpen()
+	k.bg.SetVisible(visible)
+	for i, row := range k.rows {
+		for j, key := range row {
+			key.bg.Visible = visible
+			key.label.Visible = visible
+			if !visible {
+				continue
+			}
+			key.label.Text = k.keyText(key)
+			focused := k.IsFocused() && i == k.cursorRow && j == k.cursorCol
+			switch {
+			case focused:
+				key.bg.FillColorScale = k.style.FocusedKeyColor
+				key.label.SetColorScale(k.style.FocusedTextColor)
+			case key.kind == keyboardKeyShift && k.shift:
+				key.bg.FillColorScale = k.style.ActiveKeyColor
+				key.label.SetColorScale(k.style.TextColor)
+			default:
+				key.bg.FillColorScale = k.style.KeyColor
+				key.label.SetColorScale(k.style.TextColor)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/gesignal"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+)
+
+// OptionCycler is a button that selects one of the predefined options.
+//
+// An activation selects the next option; the focused cycler
+// also reacts to the Root DecreaseAction and IncreaseAction.
+// The selection wraps around.
+type OptionCycler struct {
+	// Label is shown before the selected option text.
+	Label string
+
+	// EventChanged is emitted with the new option index when it's changed by a user.
+	EventChanged  gesignal.Event[int]
+	eventDisposed gesignal.Event[*OptionCycler]
+
+	options  []string
+	selected int
+
+	button *Button
+
+	root *Root
+}
+
+func (r *Root) NewOptionCycler(style ButtonStyle, options []string) *OptionCycler {
+	if len(options) == 0 {
+		panic("option cycler needs at least one option")
+	}
+	e := &OptionCycler{
+		options: options,
+		button:  r.NewButton(style),
+		root:    r,
+	}
+	e.button.EventActivated.Connect(e, func(*Button) {
+		e.cycle(1)
+	})
+	e.eventDisposed.Connect(r, func(c *OptionCycler) {
+		if r.disposed {
+			return
+		}
+		r.elems = xslices.RemoveIf(r.elems, func(e uiElement) bool {
+			return c == e
+		})
+	})
+	r.elems = append(r.elems, e)
+	return e
+}
+
+// Button returns the underlying button.
+// It can be used to connect the cycler with other input elements.
+func (c *OptionCycler) Button() *Button { return c.button }
+
+func (c *OptionCycler) Selected() int { return c.selected }
+
+func (c *OptionCycler) SelectedOption() string { return c.options[c.selected] }
+
+// Select changes the selected option without emitting the EventChanged.
+func (c *OptionCycler) Select(index int) {
+	if index < 0 || index >= len(c.options) {
+		panic("option index out of range")
+	}
+	c.selected = index
+}
+
+func (c *OptionCycler) Init(scene *ge.Scene) {
+	c.button.Init(scene)
+	c.button.Text = c.text()
+}
+
+func (c *OptionCycler) preferredSize() gmath.Vec { return c.button.preferredSize() }
+
+func (c *OptionCycler) setLayout(pos ge.Pos, size gmath.Vec) { c.button.setLayout(pos, size) }
+
+func (c *OptionCycler) IsDisposed() bool { return c.button.IsDisposed() }
+
+func (c *OptionCycler) Dispose() {
+	c.eventDisposed.Emit(c)
+	c.button.Dispose()
+}
+
+func (c *OptionCycler) Update(delta float64) {
+	c.button.Update(delta)
+	if c.button.IsFocused() && !c.button.IsDisabled() {
+		if c.root.actionIsJustPressed(c.root.DecreaseAction) {
+			c.cycle(-1)
+		}
+		if c.root.actionIsJustPressed(c.root.IncreaseAction) {
+			c.cycle(1)
+		}
+	}
+	c.button.Text = c.text()
+}
+
+func (c *OptionCycler) cycle(delta int) {
+	c.selected = (c.selected + delta + len(c.options)) % len(c.options)
+	c.EventChanged.Emit(c.selected)
+}
+
+func (c *OptionCycler) text() string {
+	s := "< " + c.options[c.selected] + " >"
+	if c.Label == "" {
+		return s
+	}
+	return c.Label + ": " + s
+}
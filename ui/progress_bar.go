@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/gesignal"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+)
+
+// ProgressBar displays a [0, 1] value as a partially filled bar.
+//
+// It's not an input element, so it can't be focused.
+type ProgressBar struct {
+	Visible bool
+
+	Pos ge.Pos
+
+	// EventFilled is emitted when the value reaches 1.
+	EventFilled   gesignal.Event[*ProgressBar]
+	eventDisposed gesignal.Event[*ProgressBar]
+
+	style ProgressBarStyle
+
+	value float64
+
+	bg   *background
+	fill *ge.Rect
+
+	size gmath.Vec
+
+	root *Root
+}
+
+type ProgressBarStyle struct {
+	Width  float64
+	Height float64
+
+	BorderWidth float64
+
+	BorderColor     ge.ColorScale
+	BackgroundColor ge.ColorScale
+	FillColor       ge.ColorScale
+
+	// Frame replaces the rect background with a textured frame.
+	// The background color is used as the frame color scale then,
+	// the border settings are ignored.
+	Frame *Frame
+}
+
+func DefaultProgressBarStyle() ProgressBarStyle {
+	return ProgressBarStyle{
+		Width:       256,
+		Height:      24,
+		BorderWidth: 1,
+
+		BorderColor:     grayColor,
+		BackgroundColor: darkGrayColor,
+		FillColor:       grayColor,
+	}
+}
+
+func (style ProgressBarStyle) Resized(w, h float64) ProgressBarStyle {
+	style.Width = w
+	style.Height = h
+	return style
+}
+
+func (r *Root) NewProgressBar(style ProgressBarStyle) *ProgressBar {
+	e := &ProgressBar{
+		style:   style,
+		Visible: true,
+		root:    r,
+	}
+	e.eventDisposed.Connect(r, func(b *ProgressBar) {
+		if r.disposed {
+			return
+		}
+		r.elems = xslices.RemoveIf(r.elems, func(e uiElement) bool {
+			return b == e
+		})
+	})
+	r.elems = append(r.elems, e)
+	return e
+}
+
+func (b *ProgressBar) Value() float64 { return b.value }
+
+// SetValue changes the bar value; it's clamped to [0, 1].
+func (b *ProgressBar) SetValue(v float64) {
+	v = gmath.Clamp(v, 0, 1)
+	if v == b.value {
+		return
+	}
+	b.value = v
+	if b.fill != nil {
+		b.updateFill()
+	}
+	if v == 1 {
+		b.EventFilled.Emit(b)
+	}
+}
+
+func (b *ProgressBar) Init(scene *ge.Scene) {
+	if b.size.IsZero() {
+		b.size = b.preferredSize()
+	}
+
+	b.bg = newBackground(scene, b.Pos, b.size.X, b.size.Y, b.style.BorderWidth, b.style.Frame, nil)
+	b.bg.SetColors(b.style.BackgroundColor, b.style.BorderColor)
+
+	b.fill = ge.NewRect(scene.Context(), 0, 0)
+	b.fill.Centered = false
+	b.fill.FillColorScale = b.style.FillColor
+	scene.AddGraphics(b.fill)
+
+	b.updateFill()
+}
+
+func (b *ProgressBar) preferredSize() gmath.Vec {
+	return gmath.Vec{X: b.style.Width, Y: b.style.Height}
+}
+
+func (b *ProgressBar) setLayout(pos ge.Pos, size gmath.Vec) {
+	b.Pos = pos
+	b.size = size
+	if b.fill == nil {
+		return // Will be applied during the Init
+	}
+	b.bg.SetRect(pos, size)
+	b.updateFill()
+}
+
+func (b *ProgressBar) Update(delta float64) {
+	b.bg.SetVisible(b.Visible)
+	b.fill.Visible = b.Visible && b.value != 0
+}
+
+func (b *ProgressBar) IsDisposed() bool {
+	return b.bg.IsDisposed()
+}
+
+func (b *ProgressBar) Dispose() {
+	b.eventDisposed.Emit(b)
+	b.bg.Dispose()
+	b.fill.Dispose()
+}
+
+func (b *ProgressBar) updateFill() {
+	border := b.style.BorderWidth
+	b.fill.Pos = b.Pos.WithOffset(border, border)
+	b.fill.Width = (b.size.X - border*2) * b.value
+	b.fill.Height = b.size.Y - border*2
+}
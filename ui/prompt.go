@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/gesignal"
+	"github.com/quasilyte/ge/input"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+	"golang.org/x/image/font"
+)
+
+// Prompt is a text line with inline input glyphs, like "Press [A] to fire".
+//
+// The glyphs are selected for the last used device (see Handler.LastDevice),
+// so the prompt is updated when a player switches between a keyboard and a gamepad.
+// If there is no glyph for a key, its name is rendered instead.
+type Prompt struct {
+	Visible bool
+
+	Pos ge.Pos
+
+	style PromptStyle
+
+	format  string
+	actions []input.Action
+
+	eventDisposed gesignal.Event[*Prompt]
+
+	segments     []promptSegment
+	device       input.DeviceKind
+	gamepadStyle input.GamepadStyle
+	dirty        bool
+
+	face      font.Face
+	capHeight float64
+	size      gmath.Vec
+	scene     *ge.Scene
+
+	disposed bool
+
+	root *Root
+}
+
+type PromptStyle struct {
+	Font      resource.FontID
+	TextColor ge.ColorScale
+
+	Glyphs *GlyphRegistry
+
+	// GlyphSpacing is a horizontal space added around the glyphs.
+	GlyphSpacing float64
+}
+
+type promptSegment struct {
+	offset float64
+	label  *ge.Label
+	sprite *ge.Sprite
+}
+
+func DefaultPromptStyle() PromptStyle {
+	return PromptStyle{
+		TextColor:    grayColor,
+		GlyphSpacing: 4,
+	}
+}
+
+func (r *Root) NewPrompt(style PromptStyle) *Prompt {
+	e := &Prompt{
+		style:   style,
+		Visible: true,
+		root:    r,
+	}
+	e.eventDisposed.Connect(r, func(p *Prompt) {
+		if r.disposed {
+			return
+		}
+		r.elems = xslices.RemoveIf(r.elems, func(e uiElement) bool {
+			return p == e
+		})
+	})
+	r.elems = append(r.elems, e)
+	return e
+}
+
+// SetText assigns the prompt contents.
+// Every %s inside the format is replaced with the corresponding action glyph.
+// No other formatting directives are supported.
+//
+//	p.SetText("Press %s to fire", ActionFire)
+func (p *Prompt) SetText(format string, actions ...input.Action) {
+	if strings.Count(format, "%s") != len(actions) {
+		panic("prompt format placeholders and actions count mismatch")
+	}
+	p.format = format
+	p.actions = actions
+	p.dirty = true
+}
+
+func (p *Prompt) Init(scene *ge.Scene) {
+	p.scene = scene
+	p.face = scene.Context().Loader.LoadFont(p.style.Font).Face
+	p.capHeight = float64(p.face.Metrics().CapHeight.Floor())
+	if p.capHeight < 0 {
+		p.capHeight = -p.capHeight
+	}
+	p.dirty = true
+	scene.AddGraphics(p)
+}
+
+func (p *Prompt) preferredSize() gmath.Vec { return p.size }
+
+// setLayout only moves the prompt: its size is defined by the contents.
+func (p *Prompt) setLayout(pos ge.Pos, size gmath.Vec) { p.Pos = pos }
+
+func (p *Prompt) IsDisposed() bool { return p.disposed }
+
+func (p *Prompt) Dispose() {
+	p.eventDisposed.Emit(p)
+	p.disposed = true
+}
+
+func (p *Prompt) Update(delta float64) {
+	device := p.root.input.LastDevice()
+	gamepadStyle := p.root.input.GamepadStyle()
+	if device != p.device || gamepadStyle != p.gamepadStyle {
+		p.device = device
+		p.gamepadStyle = gamepadStyle
+		p.dirty = true
+	}
+	if p.dirty {
+		p.dirty = false
+		p.layout()
+	}
+}
+
+func (p *Prompt) Draw(screen *ebiten.Image) {
+	if !p.Visible {
+		return
+	}
+	for _, s := range p.segments {
+		if s.label != nil {
+			s.label.Pos = p.Pos.WithOffset(s.offset, 0)
+			s.label.Draw(screen)
+			continue
+		}
+		s.sprite.Pos = p.Pos.WithOffset(s.offset, p.capHeight/2)
+		s.sprite.Draw(screen)
+	}
+}
+
+func (p *Prompt) layout() {
+	p.segments = p.segments[:0]
+
+	mask := p.device
+	if mask == input.KeyboardDevice || mask == input.MouseDevice {
+		mask = input.KeyboardDevice | input.MouseDevice
+	}
+	gamepadStyle := input.GamepadStyleGeneric
+	if mask == input.GamepadDevice {
+		gamepadStyle = p.gamepadStyle
+	}
+
+	offset := 0.0
+	var text strings.Builder
+	flushText := func() {
+		if text.Len() == 0 {
+			return
+		}
+		s := text.String()
+		l := ge.NewLabel(p.face)
+		l.Text = s
+		l.SetColorScale(p.style.TextColor)
+		p.segments = append(p.segments, promptSegment{offset: offset, label: l})
+		offset += float64(font.MeasureString(p.face, s).Round())
+		text.Reset()
+	}
+
+	parts := strings.Split(p.format, "%s")
+	for i, part := range parts {
+		text.WriteString(part)
+		if i == len(parts)-1 {
+			break
+		}
+		keys := p.root.input.ActionKeys(p.actions[i], mask)
+		if len(keys) == 0 {
+			continue
+		}
+		k := keys[0]
+		var glyph Glyph
+		hasGlyph := false
+		if p.style.Glyphs != nil {
+			glyph, hasGlyph = p.style.Glyphs.Find(k, gamepadStyle)
+		}
+		if !hasGlyph {
+			text.WriteString("[" + k.String() + "]")
+			continue
+		}
+		flushText()
+		s := ge.NewSprite(p.scene.Context())
+		s.SetImage(p.scene.LoadImage(glyph.Image))
+		s.FrameOffset.X = float64(glyph.Frame) * s.FrameWidth
+		offset += p.style.GlyphSpacing
+		p.segments = append(p.segments, promptSegment{offset: offset + s.FrameWidth/2, sprite: s})
+		offset += s.FrameWidth + p.style.GlyphSpacing
+	}
+	flushText()
+
+	p.size = gmath.Vec{X: offset, Y: float64(p.face.Metrics().Height.Ceil())}
+}
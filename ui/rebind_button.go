@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/gesignal"
+	"github.com/quasilyte/ge/input"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+)
+
+// RebindButton is a button that changes the action keyboard binding.
+//
+// When activated, the button waits for a new keys combination
+// (see input.KeyScanner) and puts it into the keymap instead of
+// the first keyboard key of the action.
+// Pressing escape cancels the rebinding.
+//
+// While the button is waiting for the input, a modal input context is pushed,
+// so the keys that are being pressed don't reach the game and the UI navigation.
+type RebindButton struct {
+	// Label is an action name that is shown before the key names.
+	Label string
+
+	// ListeningText is shown instead of the key names while waiting for the input.
+	ListeningText string
+
+	EventRebound  gesignal.Event[input.Key]
+	eventDisposed gesignal.Event[*RebindButton]
+
+	keymap    input.Keymap
+	action    input.Action
+	scanner   *input.KeyScanner
+	listening bool
+
+	// modal is an input context that blocks the other handlers while listening.
+	modal *input.Handler
+
+	button *Button
+
+	root *Root
+}
+
+func (r *Root) NewRebindButton(style ButtonStyle, keymap input.Keymap, action input.Action) *RebindButton {
+	e := &RebindButton{
+		ListeningText: "...",
+		keymap:        keymap,
+		action:        action,
+		scanner:       input.NewKeyScanner(r.input),
+		button:        r.NewButton(style),
+		root:          r,
+	}
+	e.button.EventActivated.Connect(e, func(*Button) {
+		if e.listening {
+			return
+		}
+		e.listening = true
+		e.modal = r.input.PushContext(input.ContextConfig{Modal: true})
+		e.button.Text = e.Label + ": " + e.ListeningText
+	})
+	e.eventDisposed.Connect(r, func(b *RebindButton) {
+		if r.disposed {
+			return
+		}
+		r.elems = xslices.RemoveIf(r.elems, func(e uiElement) bool {
+			return b == e
+		})
+	})
+	r.elems = append(r.elems, e)
+	return e
+}
+
+// Button returns the underlying button.
+// It can be used to connect the button with other input elements.
+func (b *RebindButton) Button() *Button { return b.button }
+
+// IsListening reports whether the button waits for a new binding.
+func (b *RebindButton) IsListening() bool { return b.listening }
+
+func (b *RebindButton) Init(scene *ge.Scene) {
+	b.button.Init(scene)
+	b.button.Text = b.bindingText()
+}
+
+func (b *RebindButton) preferredSize() gmath.Vec { return b.button.preferredSize() }
+
+func (b *RebindButton) setLayout(pos ge.Pos, size gmath.Vec) { b.button.setLayout(pos, size) }
+
+func (b *RebindButton) IsDisposed() bool { return b.button.IsDisposed() }
+
+func (b *RebindButton) Dispose() {
+	b.stopListening()
+	b.eventDisposed.Emit(b)
+	b.button.Dispose()
+}
+
+func (b *RebindButton) Update(delta float64) {
+	b.button.Update(delta)
+	if !b.listening {
+		b.button.Text = b.bindingText()
+		return
+	}
+
+	k, status := b.scanner.Scan()
+	switch status {
+	case input.KeyScanChanged:
+		b.button.Text = b.Label + ": " + k.String()
+	case input.KeyScanCompleted:
+		b.stopListening()
+		if k != input.KeyEscape {
+			b.keymap.Rebind(b.action, input.KeyboardDevice, k)
+			b.EventRebound.Emit(k)
+		}
+		b.button.Text = b.bindingText()
+	}
+}
+
+func (b *RebindButton) stopListening() {
+	if !b.listening {
+		return
+	}
+	b.listening = false
+	b.root.input.RemoveContext(b.modal)
+	b.modal = nil
+}
+
+func (b *RebindButton) bindingText() string {
+	var names []string
+	for _, k := range b.keymap[b.action] {
+		if k.Device() == input.KeyboardDevice {
+			names = append(names, k.String())
+		}
+	}
+	return b.Label + ": " + strings.Join(names, ", ")
+}
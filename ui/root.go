@@ -13,12 +13,29 @@ type Root struct {
 	PrevInputAction  input.Action
 	NextInputAction  input.Action
 
+	// DecreaseAction and IncreaseAction change the focused
+	// slider value or the option cycler selection.
+	DecreaseAction input.Action
+	IncreaseAction input.Action
+
+	// ScrollAction scrolls the hovered scroll list.
+	// It should be bound to the KeyWheelVertical key.
+	ScrollAction input.Action
+
+	// DragAction is used to drag the sliders and scroll lists on touch devices.
+	// It should be bound to the KeyTouchDrag key.
+	DragAction input.Action
+
 	elems []uiElement
 
 	inputElems  []inputElement
 	focused     inputElement
 	lastFocused inputElement
 
+	// editingInput is a text field that owns the text input session.
+	// All fields share the same input handler, so only one of them can be edited.
+	editingInput *TextInput
+
 	ctx   *ge.Context
 	input *input.Handler
 
@@ -32,6 +49,10 @@ func NewRoot(ctx *ge.Context, h *input.Handler) *Root {
 		ActivationAction: actionUnset,
 		PrevInputAction:  actionUnset,
 		NextInputAction:  actionUnset,
+		DecreaseAction:   actionUnset,
+		IncreaseAction:   actionUnset,
+		ScrollAction:     actionUnset,
+		DragAction:       actionUnset,
 	}
 }
 
@@ -39,17 +60,32 @@ func (r *Root) Init(scene *ge.Scene) {}
 
 func (r *Root) Update(delta float64) {
 	if r.PrevInputAction != actionUnset {
-		if r.input.ActionIsJustPressed(r.PrevInputAction) {
+		if r.input.ActionIsJustPressed(r.PrevInputAction) && !r.navigateFocused(false) {
 			r.FocusPrevInput()
 		}
 	}
 	if r.NextInputAction != actionUnset {
-		if r.input.ActionIsJustPressed(r.NextInputAction) {
+		if r.input.ActionIsJustPressed(r.NextInputAction) && !r.navigateFocused(true) {
 			r.FocusNextInput()
 		}
 	}
 }
 
+// navigateFocused gives the focused element a chance
+// to handle the navigation action by itself.
+func (r *Root) navigateFocused(next bool) bool {
+	if e, ok := r.focused.(navigationHandler); ok {
+		return e.handleNavigation(next)
+	}
+	return false
+}
+
+// actionIsJustPressed is like ActionIsJustPressed, but it also
+// reports false for the unset actions.
+func (r *Root) actionIsJustPressed(action input.Action) bool {
+	return action != actionUnset && r.input.ActionIsJustPressed(action)
+}
+
 func (r *Root) IsDisposed() bool {
 	return r.disposed
 }
@@ -203,6 +239,13 @@ type inputElement interface {
 	setNextInput(inputElement)
 }
 
+// navigationHandler is implemented by the elements that use the
+// prev/next input actions by themselves, like the scroll list.
+// The handleNavigation returns false to let the root move the focus.
+type navigationHandler interface {
+	handleNavigation(next bool) bool
+}
+
 type uiElement interface {
 	Dispose()
 	IsDisposed() bool
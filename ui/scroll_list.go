@@ -0,0 +1,419 @@
+package ui
+
+import (
+	"math"
+
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/gesignal"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+)
+
+// ScrollList is a vertical list of text items with a single selected item.
+//
+// The focused list moves its selection with the Root PrevInputAction and NextInputAction;
+// the focus leaves the list when the selection reaches its first or last item.
+// The hovered list is scrolled with the ScrollAction (mouse wheel)
+// and the DragAction (touch drag).
+// A click or a tap selects and activates the item.
+type ScrollList struct {
+	Visible bool
+
+	Pos ge.Pos
+
+	PrevInput inputElement
+	NextInput inputElement
+
+	// EventSelected is emitted with the item index when the selection is changed by a user.
+	EventSelected gesignal.Event[int]
+
+	// EventActivated is emitted with the activated item index.
+	EventActivated gesignal.Event[int]
+
+	eventDisposed gesignal.Event[*ScrollList]
+
+	checkKeyboardInput bool
+	disabled           bool
+	style              ScrollListStyle
+
+	items    []string
+	selected int
+	offset   int
+
+	// dragDistance accumulates the touch drag distance
+	// that is not enough to scroll by an entire item yet.
+	dragDistance float64
+
+	bg        *background
+	highlight *ge.Rect
+	scrollbar *ge.Rect
+	rows      []*ge.Label
+
+	scene *ge.Scene
+
+	size gmath.Vec
+	geom gmath.Rect
+
+	root *Root
+}
+
+type ScrollListStyle struct {
+	Width  float64
+	Height float64
+
+	ItemHeight     float64
+	ScrollbarWidth float64
+
+	BorderWidth float64
+
+	Font resource.FontID
+
+	BorderColor     ge.ColorScale
+	BackgroundColor ge.ColorScale
+	TextColor       ge.ColorScale
+
+	SelectedTextColor ge.ColorScale
+	HighlightColor    ge.ColorScale
+	ScrollbarColor    ge.ColorScale
+
+	FocusedBorderColor ge.ColorScale
+
+	// Frame replaces the rect background with a textured frame.
+	// The background color is used as the frame color scale then,
+	// the border settings are ignored.
+	Frame *Frame
+}
+
+func DefaultScrollListStyle() ScrollListStyle {
+	return ScrollListStyle{
+		Width:          256,
+		Height:         256,
+		ItemHeight:     32,
+		ScrollbarWidth: 6,
+		BorderWidth:    1,
+
+		BorderColor:     grayColor,
+		BackgroundColor: darkGrayColor,
+		TextColor:       grayColor,
+
+		SelectedTextColor: whiteColor,
+		HighlightColor:    withAlpha(grayColor, 0.3),
+		ScrollbarColor:    grayColor,
+
+		FocusedBorderColor: whiteColor,
+	}
+}
+
+func (style ScrollListStyle) Resized(w, h float64) ScrollListStyle {
+	style.Width = w
+	style.Height = h
+	return style
+}
+
+func (r *Root) NewScrollList(style ScrollListStyle) *ScrollList {
+	if style.ItemHeight <= 0 {
+		panic("scroll list item height should be positive")
+	}
+	e := &ScrollList{
+		style:    style,
+		Visible:  true,
+		selected: -1,
+		root:     r,
+	}
+	e.eventDisposed.Connect(r, func(l *ScrollList) {
+		if r.disposed {
+			return
+		}
+		r.inputElems = xslices.RemoveIf(r.inputElems, func(e inputElement) bool {
+			return l == e
+		})
+	})
+	r.inputElems = append(r.inputElems, e)
+	return e
+}
+
+// SetItems replaces the list contents.
+// The first item becomes selected.
+func (l *ScrollList) SetItems(items []string) {
+	l.items = items
+	l.offset = 0
+	l.selected = -1
+	if len(items) != 0 {
+		l.selected = 0
+	}
+}
+
+func (l *ScrollList) Items() []string { return l.items }
+
+// Selected returns the selected item index.
+// It returns -1 for the empty list.
+func (l *ScrollList) Selected() int { return l.selected }
+
+// Select changes the selected item without emitting the EventSelected.
+// The list is scrolled to make the selected item visible.
+func (l *ScrollList) Select(index int) {
+	if index < 0 || index >= len(l.items) {
+		panic("scroll list index out of range")
+	}
+	l.selected = index
+	l.scrollToSelected()
+}
+
+// ScrollOffset returns the index of the first visible item.
+func (l *ScrollList) ScrollOffset() int { return l.offset }
+
+func (l *ScrollList) Init(scene *ge.Scene) {
+	l.scene = scene
+	if l.size.IsZero() {
+		l.size = l.preferredSize()
+	}
+	l.geom = widgetRect(l.Pos, l.size)
+
+	l.bg = newBackground(scene, l.Pos, l.size.X, l.size.Y, l.style.BorderWidth, l.style.Frame, nil)
+
+	l.highlight = ge.NewRect(scene.Context(), 0, l.style.ItemHeight)
+	l.highlight.Centered = false
+	l.highlight.FillColorScale = l.style.HighlightColor
+	scene.AddGraphics(l.highlight)
+
+	l.scrollbar = ge.NewRect(scene.Context(), l.style.ScrollbarWidth, 0)
+	l.scrollbar.Centered = false
+	l.scrollbar.FillColorScale = l.style.ScrollbarColor
+	scene.AddGraphics(l.scrollbar)
+
+	l.createRows()
+	l.updateColors()
+}
+
+func (l *ScrollList) preferredSize() gmath.Vec {
+	return gmath.Vec{X: l.style.Width, Y: l.style.Height}
+}
+
+func (l *ScrollList) setLayout(pos ge.Pos, size gmath.Vec) {
+	l.Pos = pos
+	l.size = size
+	if l.scene == nil {
+		return // Will be applied during the Init
+	}
+	l.bg.SetRect(pos, size)
+	l.createRows()
+}
+
+func (l *ScrollList) prevInput() inputElement     { return l.PrevInput }
+func (l *ScrollList) nextInput() inputElement     { return l.NextInput }
+func (l *ScrollList) setPrevInput(e inputElement) { l.PrevInput = e }
+func (l *ScrollList) setNextInput(e inputElement) { l.NextInput = e }
+
+func (l *ScrollList) IsDisabled() bool      { return l.disabled }
+func (l *ScrollList) IsFocused() bool       { return l.root.focused == l }
+func (l *ScrollList) SetFocus(focused bool) { l.root.setFocus(l, focused) }
+
+func (l *ScrollList) SetDisabled(disabled bool) {
+	if l.disabled == disabled {
+		return
+	}
+	l.disabled = disabled
+	if l.disabled {
+		l.SetFocus(false)
+	}
+}
+
+func (l *ScrollList) onFocusChanged(focused bool) {
+	l.updateColors()
+}
+
+func (l *ScrollList) handleNavigation(next bool) bool {
+	index := l.selected - 1
+	if next {
+		index = l.selected + 1
+	}
+	if l.selected == -1 || index < 0 || index >= len(l.items) {
+		return false
+	}
+	l.selectItem(index)
+	return true
+}
+
+func (l *ScrollList) Update(delta float64) {
+	l.geom = widgetRect(l.Pos, l.size)
+	if !l.disabled {
+		if l.geom.Contains(l.root.input.CursorPos()) {
+			l.root.setFocus(l, true)
+			l.checkScrollInput()
+		}
+		l.checkDragInput()
+		l.checkInput()
+	}
+
+	l.updateRows()
+	l.bg.SetVisible(l.Visible)
+
+	l.checkKeyboardInput = l.IsFocused()
+}
+
+func (l *ScrollList) IsDisposed() bool {
+	return l.bg.IsDisposed()
+}
+
+func (l *ScrollList) Dispose() {
+	l.eventDisposed.Emit(l)
+	l.bg.Dispose()
+	l.highlight.Dispose()
+	l.scrollbar.Dispose()
+	for _, row := range l.rows {
+		row.Dispose()
+	}
+}
+
+func (l *ScrollList) numVisibleRows() int {
+	n := int((l.size.Y - 2*l.style.BorderWidth) / l.style.ItemHeight)
+	return gmath.ClampMin(n, 1)
+}
+
+func (l *ScrollList) createRows() {
+	for _, row := range l.rows {
+		row.Dispose()
+	}
+	l.rows = l.rows[:0]
+	for i := 0; i < l.numVisibleRows(); i++ {
+		row := l.scene.NewLabel(l.style.Font)
+		row.AlignVertical = ge.AlignVerticalCenter
+		row.Pos = l.Pos.WithOffset(l.style.BorderWidth*2, l.style.BorderWidth+float64(i)*l.style.ItemHeight)
+		row.Width = l.size.X - l.style.BorderWidth*4 - l.style.ScrollbarWidth
+		row.Height = l.style.ItemHeight
+		l.scene.AddGraphics(row)
+		l.rows = append(l.rows, row)
+	}
+	l.scrollTo(l.offset)
+}
+
+func (l *ScrollList) updateColors() {
+	if l.bg == nil {
+		return
+	}
+	if l.IsFocused() {
+		l.bg.SetColors(l.style.BackgroundColor, l.style.FocusedBorderColor)
+	} else {
+		l.bg.SetColors(l.style.BackgroundColor, l.style.BorderColor)
+	}
+}
+
+func (l *ScrollList) updateRows() {
+	innerWidth := l.size.X - l.style.BorderWidth*2
+	for i, row := range l.rows {
+		index := l.offset + i
+		row.Visible = l.Visible && index < len(l.items)
+		if !row.Visible {
+			continue
+		}
+		row.Text = l.items[index]
+		if index == l.selected {
+			row.SetColorScale(l.style.SelectedTextColor)
+		} else {
+			row.SetColorScale(l.style.TextColor)
+		}
+	}
+
+	selectedRow := l.selected - l.offset
+	l.highlight.Visible = l.Visible && l.selected != -1 && selectedRow >= 0 && selectedRow < len(l.rows)
+	if l.highlight.Visible {
+		l.highlight.Pos = l.Pos.WithOffset(l.style.BorderWidth, l.style.BorderWidth+float64(selectedRow)*l.style.ItemHeight)
+		l.highlight.Width = innerWidth - l.style.ScrollbarWidth
+	}
+
+	l.scrollbar.Visible = l.Visible && len(l.items) > len(l.rows)
+	if l.scrollbar.Visible {
+		innerHeight := l.size.Y - l.style.BorderWidth*2
+		l.scrollbar.Height = innerHeight * float64(len(l.rows)) / float64(len(l.items))
+		y := innerHeight * float64(l.offset) / float64(len(l.items))
+		l.scrollbar.Pos = l.Pos.WithOffset(l.style.BorderWidth+innerWidth-l.style.ScrollbarWidth, l.style.BorderWidth+y)
+	}
+}
+
+func (l *ScrollList) checkScrollInput() {
+	if l.root.ScrollAction == actionUnset {
+		return
+	}
+	v := l.root.input.ActionVector(l.root.ScrollAction).Y
+	if v == 0 {
+		return
+	}
+	// A positive wheel delta means scrolling up.
+	rows := int(math.Max(1, math.Round(math.Abs(v))))
+	if v > 0 {
+		rows = -rows
+	}
+	l.scrollTo(l.offset + rows)
+}
+
+func (l *ScrollList) checkDragInput() {
+	if l.root.DragAction == actionUnset {
+		return
+	}
+	info, ok := l.root.input.PressedActionInfo(l.root.DragAction)
+	if !ok || !l.geom.Contains(info.StartPos) {
+		l.dragDistance = 0
+		return
+	}
+	// Dragging the contents up reveals the next items.
+	l.dragDistance -= info.Delta.Y
+	for l.dragDistance >= l.style.ItemHeight {
+		l.dragDistance -= l.style.ItemHeight
+		l.scrollTo(l.offset + 1)
+	}
+	for l.dragDistance <= -l.style.ItemHeight {
+		l.dragDistance += l.style.ItemHeight
+		l.scrollTo(l.offset - 1)
+	}
+}
+
+func (l *ScrollList) checkInput() {
+	if l.root.ActivationAction == actionUnset {
+		return
+	}
+	info, ok := l.root.input.JustPressedActionInfo(l.root.ActivationAction)
+	if !ok {
+		return
+	}
+	if !l.checkKeyboardInput && !info.IsTouchEvent() {
+		return
+	}
+	if !info.HasPos() {
+		if l.selected != -1 {
+			l.EventActivated.Emit(l.selected)
+		}
+		return
+	}
+	if !l.geom.Contains(info.Pos) {
+		return
+	}
+	row := int((info.Pos.Y - l.geom.Min.Y - l.style.BorderWidth) / l.style.ItemHeight)
+	index := l.offset + row
+	if row < 0 || row >= len(l.rows) || index >= len(l.items) {
+		return
+	}
+	if index != l.selected {
+		l.selectItem(index)
+	}
+	l.EventActivated.Emit(index)
+}
+
+func (l *ScrollList) selectItem(index int) {
+	l.selected = index
+	l.scrollToSelected()
+	l.EventSelected.Emit(index)
+}
+
+func (l *ScrollList) scrollToSelected() {
+	if l.selected < l.offset {
+		l.scrollTo(l.selected)
+	} else if numRows := l.numVisibleRows(); l.selected >= l.offset+numRows {
+		l.scrollTo(l.selected - numRows + 1)
+	}
+}
+
+func (l *ScrollList) scrollTo(offset int) {
+	maxOffset := gmath.ClampMin(len(l.items)-l.numVisibleRows(), 0)
+	l.offset = gmath.Clamp(offset, 0, maxOffset)
+}
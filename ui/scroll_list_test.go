@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+)
+
+func newTestScrollList(numItems int) *ScrollList {
+	style := DefaultScrollListStyle()
+	style.ItemHeight = 10
+	style.BorderWidth = 1
+	l := NewRoot(&ge.Context{}, nil).NewScrollList(style)
+	// 5 rows are visible.
+	l.size = gmath.Vec{X: 100, Y: 52}
+	items := make([]string, numItems)
+	for i := range items {
+		items[i] = "item" + strconv.Itoa(i)
+	}
+	l.SetItems(items)
+	return l
+}
+
+func TestScrollListScrollTo(t *testing.T) {
+	tests := []struct {
+		numItems int
+		offset   int
+		want     int
+	}{
+		{20, -3, 0},
+		{20, 0, 0},
+		{20, 7, 7},
+		{20, 15, 15},
+		{20, 16, 15},
+		{20, 100, 15},
+		{3, 2, 0},
+		{0, 1, 0},
+	}
+
+	for _, test := range tests {
+		l := newTestScrollList(test.numItems)
+		l.scrollTo(test.offset)
+		if l.ScrollOffset() != test.want {
+			t.Fatalf("items=%d scrollTo(%d):\nhave: %d\nwant: %d", test.numItems, test.offset, l.ScrollOffset(), test.want)
+		}
+	}
+}
+
+func TestScrollListNavigation(t *testing.T) {
+	l := newTestScrollList(20)
+	var events []int
+	l.EventSelected.Connect(nil, func(index int) {
+		events = append(events, index)
+	})
+
+	steps := []struct {
+		next     bool
+		ok       bool
+		selected int
+		offset   int
+	}{
+		{false, false, 0, 0},
+		{true, true, 1, 0},
+		{true, true, 2, 0},
+		{true, true, 3, 0},
+		{true, true, 4, 0},
+		{true, true, 5, 1},
+		{true, true, 6, 2},
+		{false, true, 5, 2},
+		{false, true, 4, 2},
+		{false, true, 3, 2},
+		{false, true, 2, 2},
+		{false, true, 1, 1},
+	}
+	for i, step := range steps {
+		ok := l.handleNavigation(step.next)
+		if ok != step.ok || l.Selected() != step.selected || l.ScrollOffset() != step.offset {
+			t.Fatalf("step %d: have (%v, selected=%d, offset=%d), want (%v, selected=%d, offset=%d)",
+				i, ok, l.Selected(), l.ScrollOffset(), step.ok, step.selected, step.offset)
+		}
+	}
+	if len(events) != len(steps)-1 {
+		t.Fatalf("expected %d selection events, got %d", len(steps)-1, len(events))
+	}
+
+	l.Select(19)
+	if l.ScrollOffset() != 15 {
+		t.Fatalf("selecting the last item: offset=%d, want 15", l.ScrollOffset())
+	}
+	if l.handleNavigation(true) || l.Selected() != 19 {
+		t.Fatalf("navigation past the last item: selected=%d", l.Selected())
+	}
+
+	empty := newTestScrollList(0)
+	if empty.handleNavigation(true) || empty.handleNavigation(false) || empty.Selected() != -1 {
+		t.Fatal("empty list navigation should do nothing")
+	}
+}
@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/gesignal"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+)
+
+// Slider selects a number from a range.
+//
+// The focused slider value is changed with the Root DecreaseAction and IncreaseAction.
+// It can also be dragged with a mouse (ActivationAction) or a touch (DragAction).
+type Slider struct {
+	Visible bool
+
+	Pos ge.Pos
+
+	PrevInput inputElement
+	NextInput inputElement
+
+	// EventChanged is emitted with the new value when it's changed by a user.
+	EventChanged  gesignal.Event[float64]
+	eventDisposed gesignal.Event[*Slider]
+
+	disabled bool
+	dragging bool
+	style    SliderStyle
+
+	min   float64
+	max   float64
+	step  float64
+	value float64
+
+	track  *ge.Rect
+	fill   *ge.Rect
+	handle *background
+
+	size gmath.Vec
+	geom gmath.Rect
+
+	root *Root
+}
+
+type SliderStyle struct {
+	Width  float64
+	Height float64
+
+	TrackHeight float64
+	HandleWidth float64
+
+	BorderWidth float64
+
+	TrackColor ge.ColorScale
+	FillColor  ge.ColorScale
+
+	HandleColor       ge.ColorScale
+	HandleBorderColor ge.ColorScale
+
+	FocusedHandleColor       ge.ColorScale
+	FocusedHandleBorderColor ge.ColorScale
+
+	DisabledHandleColor ge.ColorScale
+
+	// HandleFrame replaces the rect handle with a textured frame.
+	HandleFrame *Frame
+
+	// FocusedHandleFrame is an optional frame that is used instead of the HandleFrame
+	// while the slider is focused.
+	FocusedHandleFrame *Frame
+}
+
+func DefaultSliderStyle() SliderStyle {
+	return SliderStyle{
+		Width:       256,
+		Height:      32,
+		TrackHeight: 8,
+		HandleWidth: 16,
+		BorderWidth: 1,
+
+		TrackColor: darkGrayColor,
+		FillColor:  grayColor,
+
+		HandleColor:       grayColor,
+		HandleBorderColor: darkGrayColor,
+
+		FocusedHandleColor:       whiteColor,
+		FocusedHandleBorderColor: whiteColor,
+
+		DisabledHandleColor: withAlpha(grayColor, 0.8),
+	}
+}
+
+func (style SliderStyle) Resized(w, h float64) SliderStyle {
+	style.Width = w
+	style.Height = h
+	return style
+}
+
+// NewSlider creates a slider for the [min, max] range.
+// The step is a value change granularity; a zero step makes the slider continuous.
+func (r *Root) NewSlider(style SliderStyle, min, max, step float64) *Slider {
+	if min >= max {
+		panic("slider min should be less than max")
+	}
+	e := &Slider{
+		style:   style,
+		Visible: true,
+		min:     min,
+		max:     max,
+		step:    step,
+		value:   min,
+		root:    r,
+	}
+	e.eventDisposed.Connect(r, func(s *Slider) {
+		if r.disposed {
+			return
+		}
+		r.inputElems = xslices.RemoveIf(r.inputElems, func(e inputElement) bool {
+			return s == e
+		})
+	})
+	r.inputElems = append(r.inputElems, e)
+	return e
+}
+
+func (s *Slider) Init(scene *ge.Scene) {
+	if s.size.IsZero() {
+		s.size = s.preferredSize()
+	}
+	s.geom = widgetRect(s.Pos, s.size)
+
+	s.track = ge.NewRect(scene.Context(), 0, s.style.TrackHeight)
+	s.track.Centered = false
+	s.track.FillColorScale = s.style.TrackColor
+	scene.AddGraphics(s.track)
+
+	s.fill = ge.NewRect(scene.Context(), 0, s.style.TrackHeight)
+	s.fill.Centered = false
+	s.fill.FillColorScale = s.style.FillColor
+	scene.AddGraphics(s.fill)
+
+	s.handle = newBackground(scene, s.Pos, s.style.HandleWidth, s.size.Y, s.style.BorderWidth, s.style.HandleFrame, s.style.FocusedHandleFrame)
+
+	s.updateGraphics()
+	s.updateColors()
+}
+
+func (s *Slider) preferredSize() gmath.Vec {
+	return gmath.Vec{X: s.style.Width, Y: s.style.Height}
+}
+
+func (s *Slider) setLayout(pos ge.Pos, size gmath.Vec) {
+	s.Pos = pos
+	s.size = size
+	if s.track == nil {
+		return // Will be applied during the Init
+	}
+	s.updateGraphics()
+}
+
+func (s *Slider) prevInput() inputElement     { return s.PrevInput }
+func (s *Slider) nextInput() inputElement     { return s.NextInput }
+func (s *Slider) setPrevInput(e inputElement) { s.PrevInput = e }
+func (s *Slider) setNextInput(e inputElement) { s.NextInput = e }
+
+func (s *Slider) IsDisabled() bool      { return s.disabled }
+func (s *Slider) IsFocused() bool       { return s.root.focused == s }
+func (s *Slider) SetFocus(focused bool) { s.root.setFocus(s, focused) }
+
+func (s *Slider) SetDisabled(disabled bool) {
+	if s.disabled == disabled {
+		return
+	}
+	s.disabled = disabled
+	if s.disabled {
+		s.dragging = false
+		s.SetFocus(false)
+	}
+	s.updateColors()
+}
+
+func (s *Slider) Value() float64 { return s.value }
+
+// SetValue changes the slider value without emitting the EventChanged.
+// The value is clamped and snapped to the slider step.
+func (s *Slider) SetValue(v float64) {
+	s.value = s.normalizeValue(v)
+	if s.track != nil {
+		s.updateGraphics()
+	}
+}
+
+func (s *Slider) onFocusChanged(focused bool) {
+	s.handle.SetFocused(focused)
+	s.updateColors()
+}
+
+func (s *Slider) updateColors() {
+	if s.handle == nil {
+		return
+	}
+	switch {
+	case s.disabled:
+		s.handle.SetColors(s.style.DisabledHandleColor, s.style.HandleBorderColor)
+	case s.IsFocused():
+		s.handle.SetColors(s.style.FocusedHandleColor, s.style.FocusedHandleBorderColor)
+	default:
+		s.handle.SetColors(s.style.HandleColor, s.style.HandleBorderColor)
+	}
+}
+
+func (s *Slider) Update(delta float64) {
+	s.geom = widgetRect(s.Pos, s.size)
+	if !s.disabled {
+		if s.geom.Contains(s.root.input.CursorPos()) {
+			s.root.setFocus(s, true)
+		}
+		s.checkInput()
+	}
+
+	s.track.Visible = s.Visible
+	s.fill.Visible = s.Visible
+	s.handle.SetVisible(s.Visible)
+}
+
+func (s *Slider) IsDisposed() bool {
+	return s.track.IsDisposed()
+}
+
+func (s *Slider) Dispose() {
+	s.eventDisposed.Emit(s)
+	s.track.Dispose()
+	s.fill.Dispose()
+	s.handle.Dispose()
+}
+
+func (s *Slider) checkInput() {
+	if s.IsFocused() {
+		step := s.step
+		if step == 0 {
+			step = (s.max - s.min) / 10
+		}
+		if s.root.actionIsJustPressed(s.root.DecreaseAction) {
+			s.changeValue(s.value - step)
+		}
+		if s.root.actionIsJustPressed(s.root.IncreaseAction) {
+			s.changeValue(s.value + step)
+		}
+	}
+
+	if s.root.ActivationAction != actionUnset {
+		if info, ok := s.root.input.JustPressedActionInfo(s.root.ActivationAction); ok && info.HasPos() {
+			if s.geom.Contains(info.Pos) {
+				// A touch activation is a tap, it can't be dragged.
+				s.dragging = !info.IsTouchEvent()
+				s.changeValue(s.posValue(info.Pos.X))
+			}
+		}
+		if s.dragging {
+			info, ok := s.root.input.PressedActionInfo(s.root.ActivationAction)
+			if ok && info.HasPos() {
+				s.changeValue(s.posValue(info.Pos.X))
+			} else {
+				s.dragging = false
+			}
+		}
+	}
+
+	if s.root.DragAction != actionUnset {
+		if info, ok := s.root.input.PressedActionInfo(s.root.DragAction); ok && s.geom.Contains(info.StartPos) {
+			s.changeValue(s.posValue(info.Pos.X))
+		}
+	}
+}
+
+func (s *Slider) changeValue(v float64) {
+	v = s.normalizeValue(v)
+	if v == s.value {
+		return
+	}
+	s.value = v
+	s.updateGraphics()
+	s.EventChanged.Emit(v)
+}
+
+// normalizeValue clamps v and snaps it to the slider step grid.
+//
+// When the range is not a multiple of the step, the max value
+// itself is not reachable: the last grid point below it is used instead.
+func (s *Slider) normalizeValue(v float64) float64 {
+	v = gmath.Clamp(v, s.min, s.max)
+	if s.step == 0 {
+		return v
+	}
+	// The epsilon compensates the float division errors, like 0.3/0.1=2.9999999999999996.
+	lastStep := math.Floor((s.max-s.min)/s.step + 1e-9)
+	steps := math.Min(math.Round((v-s.min)/s.step), lastStep)
+	return s.min + steps*s.step
+}
+
+// posValue converts the screen X coordinate to the slider value.
+func (s *Slider) posValue(x float64) float64 {
+	trackWidth := s.size.X - s.style.HandleWidth
+	if trackWidth <= 0 {
+		return s.min
+	}
+	t := (x - s.geom.Min.X - s.style.HandleWidth/2) / trackWidth
+	return s.min + gmath.Clamp(t, 0, 1)*(s.max-s.min)
+}
+
+func (s *Slider) updateGraphics() {
+	t := (s.value - s.min) / (s.max - s.min)
+	trackWidth := s.size.X - s.style.HandleWidth
+	dy := (s.size.Y - s.style.TrackHeight) / 2
+	trackPos := s.Pos.WithOffset(s.style.HandleWidth/2, dy)
+
+	s.track.Pos = trackPos
+	s.track.Width = trackWidth
+	s.fill.Pos = trackPos
+	s.fill.Width = trackWidth * t
+
+	s.handle.SetRect(s.Pos.WithOffset(trackWidth*t, 0), gmath.Vec{X: s.style.HandleWidth, Y: s.size.Y})
+}
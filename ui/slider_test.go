@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+)
+
+func TestSliderNormalizeValue(t *testing.T) {
+	tests := []struct {
+		min  float64
+		max  float64
+		step float64
+		v    float64
+		want float64
+	}{
+		{0, 10, 0, 3.3, 3.3},
+		{0, 10, 0, -1, 0},
+		{0, 10, 0, 12, 10},
+		{0, 10, 2, 3, 4},
+		{0, 10, 2, 2.9, 2},
+		{0, 10, 2, -5, 0},
+		{0, 10, 2, 11, 10},
+		{0, 10, 3, 10, 9},
+		{0, 10, 3, 11, 9},
+		{0, 10, 3, 7.4, 6},
+		{0, 10, 4, 10, 8},
+		{0, 10, 4, 12, 8},
+		{-1, 1, 0.5, 0.3, 0.5},
+		{-1, 1, 0.5, -0.8, -1},
+		{5, 15, 5, 12, 10},
+	}
+
+	r := NewRoot(&ge.Context{}, nil)
+	for _, test := range tests {
+		s := r.NewSlider(DefaultSliderStyle(), test.min, test.max, test.step)
+		if have := s.normalizeValue(test.v); have != test.want {
+			t.Fatalf("[%v, %v] step=%v normalize(%v):\nhave: %v\nwant: %v",
+				test.min, test.max, test.step, test.v, have, test.want)
+		}
+	}
+}
+
+func TestSliderPosValue(t *testing.T) {
+	tests := []struct {
+		width float64
+		x     float64
+		want  float64
+	}{
+		{220, 110, 0},
+		{220, 160, 2.5},
+		{220, 210, 5},
+		{220, 310, 10},
+		{220, 50, 0},
+		{220, 400, 10},
+		// The slider is too narrow to have a track.
+		{20, 110, 0},
+	}
+
+	r := NewRoot(&ge.Context{}, nil)
+	style := DefaultSliderStyle()
+	style.HandleWidth = 20
+	for _, test := range tests {
+		s := r.NewSlider(style, 0, 10, 0)
+		s.Pos.Offset = gmath.Vec{X: 100}
+		s.size = gmath.Vec{X: test.width, Y: 20}
+		s.geom = widgetRect(s.Pos, s.size)
+		if have := s.posValue(test.x); have != test.want {
+			t.Fatalf("width=%v posValue(%v):\nhave: %v\nwant: %v", test.width, test.x, have, test.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"unicode"
+
+	"github.com/quasilyte/ge/input"
+	"github.com/quasilyte/gmath"
+)
+
+// textEditor is a single line text editing model used by the TextInput.
+//
+// The positions are rune indexes.
+// The selection is a range between the anchor and the caret.
+type textEditor struct {
+	text   []rune
+	caret  int
+	anchor int
+}
+
+func (e *textEditor) setText(s string) {
+	e.text = []rune(s)
+	e.caret = len(e.text)
+	e.anchor = e.caret
+}
+
+func (e *textEditor) hasSelection() bool { return e.caret != e.anchor }
+
+func (e *textEditor) selection() (int, int) {
+	if e.caret < e.anchor {
+		return e.caret, e.anchor
+	}
+	return e.anchor, e.caret
+}
+
+func (e *textEditor) selectedText() string {
+	from, to := e.selection()
+	return string(e.text[from:to])
+}
+
+func (e *textEditor) deleteSelection() bool {
+	if !e.hasSelection() {
+		return false
+	}
+	from, to := e.selection()
+	e.text = append(e.text[:from], e.text[to:]...)
+	e.caret = from
+	e.anchor = from
+	return true
+}
+
+// insert replaces the selection with the runes.
+// The runes that exceed the maxLength are discarded (0 means "no limit").
+func (e *textEditor) insert(runes []rune, maxLength int) bool {
+	changed := e.deleteSelection()
+	if maxLength > 0 {
+		free := maxLength - len(e.text)
+		if free < len(runes) {
+			runes = runes[:gmath.ClampMin(free, 0)]
+		}
+	}
+	if len(runes) == 0 {
+		return changed
+	}
+	text := make([]rune, 0, len(e.text)+len(runes))
+	text = append(text, e.text[:e.caret]...)
+	text = append(text, runes...)
+	text = append(text, e.text[e.caret:]...)
+	e.text = text
+	e.caret += len(runes)
+	e.anchor = e.caret
+	return true
+}
+
+func (e *textEditor) moveCaret(pos int, extend bool) {
+	if !extend && e.hasSelection() {
+		e.anchor = e.caret
+	}
+	e.caret = pos
+	if !extend {
+		e.anchor = pos
+	}
+}
+
+// apply executes the editing command.
+// The clipboard commands (copy, cut and paste) are handled by the caller.
+// It reports whether the text was changed.
+func (e *textEditor) apply(ev input.TextEditEvent) bool {
+	switch ev.Kind {
+	case input.TextEditBackspace:
+		if e.deleteSelection() {
+			return true
+		}
+		from := e.caret - 1
+		if ev.Word {
+			from = e.wordStart(e.caret)
+		}
+		return e.deleteRange(from, e.caret)
+	case input.TextEditDelete:
+		if e.deleteSelection() {
+			return true
+		}
+		to := e.caret + 1
+		if ev.Word {
+			to = e.wordEnd(e.caret)
+		}
+		return e.deleteRange(e.caret, to)
+	case input.TextEditLeft:
+		switch {
+		case ev.Word:
+			e.moveCaret(e.wordStart(e.caret), ev.Shift)
+		case e.hasSelection() && !ev.Shift:
+			from, _ := e.selection()
+			e.moveCaret(from, false)
+		case e.caret > 0:
+			e.moveCaret(e.caret-1, ev.Shift)
+		}
+	case input.TextEditRight:
+		switch {
+		case ev.Word:
+			e.moveCaret(e.wordEnd(e.caret), ev.Shift)
+		case e.hasSelection() && !ev.Shift:
+			_, to := e.selection()
+			e.moveCaret(to, false)
+		case e.caret < len(e.text):
+			e.moveCaret(e.caret+1, ev.Shift)
+		}
+	case input.TextEditHome:
+		e.moveCaret(0, ev.Shift)
+	case input.TextEditEnd:
+		e.moveCaret(len(e.text), ev.Shift)
+	case input.TextEditSelectAll:
+		e.anchor = 0
+		e.caret = len(e.text)
+	}
+	return false
+}
+
+func (e *textEditor) deleteRange(from, to int) bool {
+	if from < 0 {
+		from = 0
+	}
+	if to > len(e.text) {
+		to = len(e.text)
+	}
+	if from >= to {
+		return false
+	}
+	e.text = append(e.text[:from], e.text[to:]...)
+	e.caret = from
+	e.anchor = from
+	return true
+}
+
+// wordStart returns the beginning of the word before the pos.
+// The spaces between the pos and the word are skipped.
+func (e *textEditor) wordStart(pos int) int {
+	for pos > 0 && unicode.IsSpace(e.text[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !unicode.IsSpace(e.text[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// wordEnd returns the end of the word after the pos.
+// The spaces between the pos and the word are skipped.
+func (e *textEditor) wordEnd(pos int) int {
+	for pos < len(e.text) && unicode.IsSpace(e.text[pos]) {
+		pos++
+	}
+	for pos < len(e.text) && !unicode.IsSpace(e.text[pos]) {
+		pos++
+	}
+	return pos
+}
@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/quasilyte/ge/input"
+)
+
+func newTestTextEditor(text string, anchor, caret int) *textEditor {
+	e := &textEditor{}
+	e.setText(text)
+	e.anchor = anchor
+	e.caret = caret
+	return e
+}
+
+func TestTextEditorInsert(t *testing.T) {
+	tests := []struct {
+		text      string
+		anchor    int
+		caret     int
+		insert    string
+		maxLength int
+
+		wantText    string
+		wantCaret   int
+		wantChanged bool
+	}{
+		{"abc", 3, 3, "de", 0, "abcde", 5, true},
+		{"abc", 1, 1, "X", 0, "aXbc", 2, true},
+		{"abc", 3, 3, "", 0, "abc", 3, false},
+
+		// The chars that don't fit are discarded.
+		{"abc", 3, 3, "defg", 5, "abcde", 5, true},
+		{"abc", 0, 0, "xyz", 4, "xabc", 1, true},
+		{"abcde", 5, 5, "f", 5, "abcde", 5, false},
+		{"abcdef", 6, 6, "g", 3, "abcdef", 6, false},
+
+		// The selection is replaced.
+		{"abcde", 1, 4, "XY", 0, "aXYe", 3, true},
+		{"abcde", 4, 1, "Z", 0, "aZe", 2, true},
+		{"abcde", 1, 4, "XYZW", 4, "aXYe", 3, true},
+		{"abcde", 0, 5, "", 0, "", 0, true},
+	}
+
+	for _, test := range tests {
+		e := newTestTextEditor(test.text, test.anchor, test.caret)
+		changed := e.insert([]rune(test.insert), test.maxLength)
+		if string(e.text) != test.wantText || e.caret != test.wantCaret || e.anchor != test.wantCaret || changed != test.wantChanged {
+			t.Fatalf("insert(%q, %d) into %q [%d:%d]:\nhave: %q caret=%d anchor=%d changed=%v\nwant: %q caret=%d changed=%v",
+				test.insert, test.maxLength, test.text, test.anchor, test.caret,
+				string(e.text), e.caret, e.anchor, changed,
+				test.wantText, test.wantCaret, test.wantChanged)
+		}
+	}
+}
+
+func TestTextEditorApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		anchor int
+		caret  int
+		ev     input.TextEditEvent
+
+		wantText    string
+		wantAnchor  int
+		wantCaret   int
+		wantChanged bool
+	}{
+		{
+			name: "backspace",
+			text: "abc", anchor: 2, caret: 2,
+			ev:       input.TextEditEvent{Kind: input.TextEditBackspace},
+			wantText: "ac", wantAnchor: 1, wantCaret: 1, wantChanged: true,
+		},
+		{
+			name: "backspace at start",
+			text: "abc", anchor: 0, caret: 0,
+			ev:       input.TextEditEvent{Kind: input.TextEditBackspace},
+			wantText: "abc", wantAnchor: 0, wantCaret: 0,
+		},
+		{
+			name: "backspace selection",
+			text: "abcdef", anchor: 1, caret: 4,
+			ev:       input.TextEditEvent{Kind: input.TextEditBackspace},
+			wantText: "aef", wantAnchor: 1, wantCaret: 1, wantChanged: true,
+		},
+		{
+			name: "word backspace",
+			text: "hello world", anchor: 11, caret: 11,
+			ev:       input.TextEditEvent{Kind: input.TextEditBackspace, Word: true},
+			wantText: "hello ", wantAnchor: 6, wantCaret: 6, wantChanged: true,
+		},
+		{
+			name: "word backspace skips spaces",
+			text: "hello world  ", anchor: 13, caret: 13,
+			ev:       input.TextEditEvent{Kind: input.TextEditBackspace, Word: true},
+			wantText: "hello ", wantAnchor: 6, wantCaret: 6, wantChanged: true,
+		},
+		{
+			name: "word backspace inside word",
+			text: "hello world", anchor: 8, caret: 8,
+			ev:       input.TextEditEvent{Kind: input.TextEditBackspace, Word: true},
+			wantText: "hello rld", wantAnchor: 6, wantCaret: 6, wantChanged: true,
+		},
+		{
+			name: "delete",
+			text: "abc", anchor: 1, caret: 1,
+			ev:       input.TextEditEvent{Kind: input.TextEditDelete},
+			wantText: "ac", wantAnchor: 1, wantCaret: 1, wantChanged: true,
+		},
+		{
+			name: "delete at end",
+			text: "abc", anchor: 3, caret: 3,
+			ev:       input.TextEditEvent{Kind: input.TextEditDelete},
+			wantText: "abc", wantAnchor: 3, wantCaret: 3,
+		},
+		{
+			name: "word delete",
+			text: "hello world", anchor: 0, caret: 0,
+			ev:       input.TextEditEvent{Kind: input.TextEditDelete, Word: true},
+			wantText: " world", wantAnchor: 0, wantCaret: 0, wantChanged: true,
+		},
+		{
+			name: "word delete skips spaces",
+			text: "hello world", anchor: 5, caret: 5,
+			ev:       input.TextEditEvent{Kind: input.TextEditDelete, Word: true},
+			wantText: "hello", wantAnchor: 5, wantCaret: 5, wantChanged: true,
+		},
+		{
+			name: "word delete selection",
+			text: "hello world", anchor: 8, caret: 2,
+			ev:       input.TextEditEvent{Kind: input.TextEditDelete, Word: true},
+			wantText: "herld", wantAnchor: 2, wantCaret: 2, wantChanged: true,
+		},
+		{
+			name: "left",
+			text: "abc", anchor: 2, caret: 2,
+			ev:       input.TextEditEvent{Kind: input.TextEditLeft},
+			wantText: "abc", wantAnchor: 1, wantCaret: 1,
+		},
+		{
+			name: "left at start",
+			text: "abc", anchor: 0, caret: 0,
+			ev:       input.TextEditEvent{Kind: input.TextEditLeft},
+			wantText: "abc", wantAnchor: 0, wantCaret: 0,
+		},
+		{
+			name: "left collapses selection",
+			text: "abcdef", anchor: 1, caret: 4,
+			ev:       input.TextEditEvent{Kind: input.TextEditLeft},
+			wantText: "abcdef", wantAnchor: 1, wantCaret: 1,
+		},
+		{
+			name: "left collapses reversed selection",
+			text: "abcdef", anchor: 4, caret: 1,
+			ev:       input.TextEditEvent{Kind: input.TextEditLeft},
+			wantText: "abcdef", wantAnchor: 1, wantCaret: 1,
+		},
+		{
+			name: "shift left extends selection",
+			text: "abcdef", anchor: 1, caret: 4,
+			ev:       input.TextEditEvent{Kind: input.TextEditLeft, Shift: true},
+			wantText: "abcdef", wantAnchor: 1, wantCaret: 3,
+		},
+		{
+			name: "right",
+			text: "abc", anchor: 2, caret: 2,
+			ev:       input.TextEditEvent{Kind: input.TextEditRight},
+			wantText: "abc", wantAnchor: 3, wantCaret: 3,
+		},
+		{
+			name: "right at end",
+			text: "abc", anchor: 3, caret: 3,
+			ev:       input.TextEditEvent{Kind: input.TextEditRight},
+			wantText: "abc", wantAnchor: 3, wantCaret: 3,
+		},
+		{
+			name: "right collapses selection",
+			text: "abcdef", anchor: 1, caret: 4,
+			ev:       input.TextEditEvent{Kind: input.TextEditRight},
+			wantText: "abcdef", wantAnchor: 4, wantCaret: 4,
+		},
+		{
+			name: "right collapses reversed selection",
+			text: "abcdef", anchor: 4, caret: 1,
+			ev:       input.TextEditEvent{Kind: input.TextEditRight},
+			wantText: "abcdef", wantAnchor: 4, wantCaret: 4,
+		},
+		{
+			name: "word left",
+			text: "hello world", anchor: 11, caret: 11,
+			ev:       input.TextEditEvent{Kind: input.TextEditLeft, Word: true},
+			wantText: "hello world", wantAnchor: 6, wantCaret: 6,
+		},
+		{
+			name: "shift word right",
+			text: "hello world", anchor: 0, caret: 0,
+			ev:       input.TextEditEvent{Kind: input.TextEditRight, Word: true, Shift: true},
+			wantText: "hello world", wantAnchor: 0, wantCaret: 5,
+		},
+		{
+			name: "shift home",
+			text: "abc", anchor: 2, caret: 2,
+			ev:       input.TextEditEvent{Kind: input.TextEditHome, Shift: true},
+			wantText: "abc", wantAnchor: 2, wantCaret: 0,
+		},
+		{
+			name: "select all",
+			text: "abc", anchor: 1, caret: 1,
+			ev:       input.TextEditEvent{Kind: input.TextEditSelectAll},
+			wantText: "abc", wantAnchor: 0, wantCaret: 3,
+		},
+	}
+
+	for _, test := range tests {
+		e := newTestTextEditor(test.text, test.anchor, test.caret)
+		changed := e.apply(test.ev)
+		if string(e.text) != test.wantText || e.anchor != test.wantAnchor || e.caret != test.wantCaret || changed != test.wantChanged {
+			t.Fatalf("%s:\nhave: %q [%d:%d] changed=%v\nwant: %q [%d:%d] changed=%v",
+				test.name,
+				string(e.text), e.anchor, e.caret, changed,
+				test.wantText, test.wantAnchor, test.wantCaret, test.wantChanged)
+		}
+	}
+}
@@ -0,0 +1,584 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/gesignal"
+	"github.com/quasilyte/ge/input"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+	"golang.org/x/image/font"
+)
+
+// TextFilter reports whether the char can be typed into the TextInput.
+type TextFilter func(ch rune) bool
+
+// NumericFilter accepts the decimal digits.
+func NumericFilter(ch rune) bool { return ch >= '0' && ch <= '9' }
+
+// AlphanumericFilter accepts the letters and the digits.
+func AlphanumericFilter(ch rune) bool { return unicode.IsLetter(ch) || unicode.IsDigit(ch) }
+
+// TextInput is a single line editable text field.
+//
+// An activation starts the editing: the text input mode is enabled
+// (see Handler.StartTextInput) and the keyboard is used for typing.
+// Enter submits the text; escape or a click outside of the field stops the editing.
+// The editing continues when the field loses the focus,
+// but it's stopped when another field starts the editing.
+//
+// If the editing is started with a gamepad or a touch and the field
+// has an on-screen keyboard attached (see SetOnScreenKeyboard),
+// that keyboard is opened instead.
+type TextInput struct {
+	Visible bool
+
+	Pos ge.Pos
+
+	// Placeholder is shown while the text is empty.
+	Placeholder string
+
+	// MaxLength limits the text length (in chars).
+	// A zero value means "no limit".
+	MaxLength int
+
+	// Filter rejects the chars that can't be typed.
+	// A nil filter accepts all printable chars.
+	Filter TextFilter
+
+	// Password fields render the mask chars instead of the text.
+	// Their contents can't be copied to the clipboard.
+	Password bool
+
+	PrevInput inputElement
+	NextInput inputElement
+
+	// EventChanged is emitted with the new text when it's changed by a user.
+	EventChanged gesignal.Event[string]
+
+	// EventSubmitted is emitted with the text when a user confirms the input.
+	EventSubmitted gesignal.Event[string]
+
+	eventDisposed gesignal.Event[*TextInput]
+
+	checkKeyboardInput bool
+	disabled           bool
+	editing            bool
+	keyboardMode       bool
+	style              TextInputStyle
+
+	editor textEditor
+
+	// viewStart is the first visible display char index;
+	// the long texts are scrolled to keep the caret visible.
+	viewStart  int
+	caretTimer float64
+
+	keyboard *OnScreenKeyboard
+
+	label     *ge.Label
+	bg        *background
+	caret     *ge.Rect
+	selection *ge.Rect
+
+	face       font.Face
+	lineHeight float64
+
+	// This is a scratch slice.
+	display []rune
+
+	size gmath.Vec
+	geom gmath.Rect
+
+	root *Root
+}
+
+type TextInputStyle struct {
+	Width  float64
+	Height float64
+
+	BorderWidth float64
+
+	// Padding is a horizontal space between the border and the text.
+	Padding float64
+
+	CaretWidth float64
+
+	Font resource.FontID
+
+	// PasswordMask is a char that replaces the password field text chars.
+	PasswordMask rune
+
+	BorderColor      ge.ColorScale
+	BackgroundColor  ge.ColorScale
+	TextColor        ge.ColorScale
+	PlaceholderColor ge.ColorScale
+	CaretColor       ge.ColorScale
+	SelectionColor   ge.ColorScale
+
+	FocusedBorderColor     ge.ColorScale
+	FocusedBackgroundColor ge.ColorScale
+
+	DisabledBorderColor ge.ColorScale
+	DisabledTextColor   ge.ColorScale
+
+	// Frame replaces the rect background with a textured frame.
+	// The background colors are used as the frame color scale then,
+	// the border settings are ignored.
+	Frame *Frame
+
+	// FocusedFrame is an optional frame that is used instead of the Frame
+	// while the field is focused.
+	FocusedFrame *Frame
+}
+
+func DefaultTextInputStyle() TextInputStyle {
+	return TextInputStyle{
+		Width:       256,
+		Height:      48,
+		BorderWidth: 1,
+		Padding:     8,
+		CaretWidth:  2,
+
+		PasswordMask: '*',
+
+		BorderColor:      grayColor,
+		BackgroundColor:  darkGrayColor,
+		TextColor:        whiteColor,
+		PlaceholderColor: withAlpha(grayColor, 0.6),
+		CaretColor:       whiteColor,
+		SelectionColor:   withAlpha(grayColor, 0.4),
+
+		FocusedBorderColor:     whiteColor,
+		FocusedBackgroundColor: darkGrayColor,
+
+		DisabledBorderColor: withAlpha(grayColor, 0.8),
+		DisabledTextColor:   withAlpha(grayColor, 0.8),
+	}
+}
+
+func (style TextInputStyle) Resized(w, h float64) TextInputStyle {
+	style.Width = w
+	style.Height = h
+	return style
+}
+
+func (r *Root) NewTextInput(style TextInputStyle) *TextInput {
+	e := &TextInput{
+		style:   style,
+		Visible: true,
+		root:    r,
+	}
+	e.eventDisposed.Connect(r, func(t *TextInput) {
+		if r.disposed {
+			return
+		}
+		r.inputElems = xslices.RemoveIf(r.inputElems, func(e inputElement) bool {
+			return t == e
+		})
+	})
+	r.inputElems = append(r.inputElems, e)
+	return e
+}
+
+// SetOnScreenKeyboard attaches the keyboard that is used by the gamepad and touch users.
+// One keyboard can be shared between several text inputs.
+func (t *TextInput) SetOnScreenKeyboard(k *OnScreenKeyboard) {
+	t.keyboard = k
+}
+
+func (t *TextInput) Text() string { return string(t.editor.text) }
+
+// SetText replaces the field contents without emitting the EventChanged.
+// The text is not validated with the Filter and MaxLength.
+func (t *TextInput) SetText(s string) {
+	t.editor.setText(s)
+	t.viewStart = 0
+}
+
+// IsEditing reports whether the field receives the text input.
+func (t *TextInput) IsEditing() bool { return t.editing }
+
+func (t *TextInput) Init(scene *ge.Scene) {
+	if t.size.IsZero() {
+		t.size = t.preferredSize()
+	}
+	t.geom = widgetRect(t.Pos, t.size)
+
+	t.face = scene.Context().Loader.LoadFont(t.style.Font).Face
+	t.lineHeight = float64(t.face.Metrics().Height.Ceil())
+
+	t.bg = newBackground(scene, t.Pos, t.size.X, t.size.Y, t.style.BorderWidth, t.style.Frame, t.style.FocusedFrame)
+
+	t.selection = ge.NewRect(scene.Context(), 0, 0)
+	t.selection.Centered = false
+	t.selection.FillColorScale = t.style.SelectionColor
+	scene.AddGraphics(t.selection)
+
+	t.label = scene.NewLabel(t.style.Font)
+	t.label.AlignVertical = ge.AlignVerticalCenter
+	scene.AddGraphics(t.label)
+
+	t.caret = ge.NewRect(scene.Context(), t.style.CaretWidth, 0)
+	t.caret.Centered = false
+	t.caret.FillColorScale = t.style.CaretColor
+	scene.AddGraphics(t.caret)
+
+	t.updateColors()
+	t.updateGraphics()
+}
+
+func (t *TextInput) preferredSize() gmath.Vec {
+	return gmath.Vec{X: t.style.Width, Y: t.style.Height}
+}
+
+func (t *TextInput) setLayout(pos ge.Pos, size gmath.Vec) {
+	t.Pos = pos
+	t.size = size
+	if t.label == nil {
+		return // Will be applied during the Init
+	}
+	t.bg.SetRect(pos, size)
+	t.updateGraphics()
+}
+
+func (t *TextInput) prevInput() inputElement     { return t.PrevInput }
+func (t *TextInput) nextInput() inputElement     { return t.NextInput }
+func (t *TextInput) setPrevInput(e inputElement) { t.PrevInput = e }
+func (t *TextInput) setNextInput(e inputElement) { t.NextInput = e }
+
+func (t *TextInput) IsDisabled() bool      { return t.disabled }
+func (t *TextInput) IsFocused() bool       { return t.root.focused == t }
+func (t *TextInput) SetFocus(focused bool) { t.root.setFocus(t, focused) }
+
+func (t *TextInput) SetDisabled(disabled bool) {
+	if t.disabled == disabled {
+		return
+	}
+	t.disabled = disabled
+	if t.disabled {
+		t.StopEditing()
+		t.SetFocus(false)
+	}
+	t.updateColors()
+}
+
+// onFocusChanged doesn't affect the editing: the focus follows the cursor,
+// so the field can lose it while a user is still typing.
+func (t *TextInput) onFocusChanged(focused bool) {
+	t.bg.SetFocused(focused)
+	t.updateColors()
+}
+
+// StartEditing enables the text input for this field.
+// The on-screen keyboard is opened if the last used device is a gamepad.
+func (t *TextInput) StartEditing() {
+	t.startEditing(t.keyboard != nil && t.root.input.LastDevice() == input.GamepadDevice)
+}
+
+// StopEditing disables the text input for this field.
+// The text is not submitted.
+func (t *TextInput) StopEditing() {
+	if !t.editing {
+		return
+	}
+	t.editing = false
+	// Don't end the session that belongs to another field.
+	if t.root.editingInput == t {
+		t.root.editingInput = nil
+		if t.keyboardMode {
+			t.keyboard.close()
+		} else {
+			t.root.input.StopTextInput()
+		}
+	}
+	t.keyboardMode = false
+	t.editor.moveCaret(t.editor.caret, false)
+}
+
+func (t *TextInput) startEditing(keyboardMode bool) {
+	if t.editing || t.disabled {
+		return
+	}
+	if prev := t.root.editingInput; prev != nil {
+		// Only one field can be edited at a time.
+		prev.StopEditing()
+	}
+	t.root.editingInput = t
+	t.editing = true
+	t.keyboardMode = keyboardMode
+	t.caretTimer = 0
+	t.SetFocus(true)
+	if keyboardMode {
+		t.keyboard.open(t)
+	} else {
+		imePos := t.geom.Min.Add(gmath.Vec{Y: t.size.Y})
+		t.root.input.StartTextInput(imePos)
+	}
+}
+
+func (t *TextInput) submit() {
+	text := t.Text()
+	t.StopEditing()
+	t.EventSubmitted.Emit(text)
+}
+
+func (t *TextInput) Update(delta float64) {
+	t.geom = widgetRect(t.Pos, t.size)
+	if !t.disabled {
+		if !t.keyboardMode && t.geom.Contains(t.root.input.CursorPos()) {
+			t.root.setFocus(t, true)
+		}
+		if t.editing && !t.keyboardMode {
+			t.handleTextInput()
+		}
+		t.checkInput()
+	}
+
+	t.caretTimer += delta
+	t.updateGraphics()
+	t.label.Visible = t.Visible
+	t.bg.SetVisible(t.Visible)
+
+	t.checkKeyboardInput = t.IsFocused()
+}
+
+func (t *TextInput) IsDisposed() bool {
+	return t.bg.IsDisposed()
+}
+
+func (t *TextInput) Dispose() {
+	t.StopEditing()
+	t.eventDisposed.Emit(t)
+	t.label.Dispose()
+	t.bg.Dispose()
+	t.caret.Dispose()
+	t.selection.Dispose()
+}
+
+func (t *TextInput) checkInput() {
+	if t.root.ActivationAction == actionUnset {
+		return
+	}
+	info, ok := t.root.input.JustPressedActionInfo(t.root.ActivationAction)
+	if !ok {
+		return
+	}
+	if !info.HasPos() {
+		if t.checkKeyboardInput && !t.editing {
+			t.startEditing(t.keyboard != nil && t.root.input.LastDevice() == input.GamepadDevice)
+		}
+		return
+	}
+	if !t.geom.Contains(info.Pos) {
+		// A click outside of the field; the on-screen keyboard clicks are its own.
+		if t.editing && !(t.keyboardMode && t.keyboard.geom.Contains(info.Pos)) {
+			t.StopEditing()
+		}
+		return
+	}
+	if !t.checkKeyboardInput && !info.IsTouchEvent() {
+		return
+	}
+	t.startEditing(t.keyboard != nil && info.IsTouchEvent())
+	t.editor.moveCaret(t.posToIndex(info.Pos.X), false)
+	t.caretTimer = 0
+}
+
+func (t *TextInput) handleTextInput() {
+	h := t.root.input
+	changed := false
+	if chars := h.TextInputChars(); len(chars) != 0 {
+		changed = t.insertRunes(chars)
+	}
+	for _, ev := range h.TextEditEvents() {
+		switch ev.Kind {
+		case input.TextEditCopy:
+			if t.editor.hasSelection() && !t.Password {
+				h.SetClipboardText(t.editor.selectedText())
+			}
+		case input.TextEditCut:
+			if t.editor.hasSelection() && !t.Password {
+				h.SetClipboardText(t.editor.selectedText())
+				changed = t.editor.deleteSelection() || changed
+			}
+		case input.TextEditPaste:
+			changed = t.insertRunes([]rune(ev.Text)) || changed
+		case input.TextEditSubmit:
+			t.emitChanged(changed)
+			t.submit()
+			return
+		case input.TextEditCancel:
+			t.emitChanged(changed)
+			t.StopEditing()
+			return
+		default:
+			changed = t.editor.apply(ev) || changed
+		}
+		t.caretTimer = 0
+	}
+	t.emitChanged(changed)
+}
+
+func (t *TextInput) emitChanged(changed bool) {
+	if changed {
+		t.caretTimer = 0
+		t.EventChanged.Emit(t.Text())
+	}
+}
+
+// insertRunes adds the chars that pass the filters at the caret position.
+func (t *TextInput) insertRunes(runes []rune) bool {
+	filtered := make([]rune, 0, len(runes))
+	for _, ch := range runes {
+		if !unicode.IsPrint(ch) {
+			continue
+		}
+		if t.Filter != nil && !t.Filter(ch) {
+			continue
+		}
+		filtered = append(filtered, ch)
+	}
+	return t.editor.insert(filtered, t.MaxLength)
+}
+
+// typeText is used by the on-screen keyboard.
+func (t *TextInput) typeText(s string) {
+	t.emitChanged(t.insertRunes([]rune(s)))
+}
+
+// typeEdit is used by the on-screen keyboard.
+func (t *TextInput) typeEdit(kind input.TextEditKind) {
+	switch kind {
+	case input.TextEditSubmit:
+		t.submit()
+		return
+	case input.TextEditCancel:
+		t.StopEditing()
+		return
+	}
+	t.emitChanged(t.editor.apply(input.TextEditEvent{Kind: kind}))
+}
+
+func (t *TextInput) updateColors() {
+	if t.bg == nil {
+		return
+	}
+	switch {
+	case t.disabled:
+		t.bg.SetColors(t.style.BackgroundColor, t.style.DisabledBorderColor)
+	case t.IsFocused():
+		t.bg.SetColors(t.style.FocusedBackgroundColor, t.style.FocusedBorderColor)
+	default:
+		t.bg.SetColors(t.style.BackgroundColor, t.style.BorderColor)
+	}
+}
+
+// displayText returns the rendered chars and the caret index inside them.
+// It applies the password mask and inserts the IME composition text.
+func (t *TextInput) displayText() ([]rune, int) {
+	t.display = t.display[:0]
+	if t.Password {
+		for range t.editor.text {
+			t.display = append(t.display, t.style.PasswordMask)
+		}
+		return t.display, t.editor.caret
+	}
+	caret := t.editor.caret
+	t.display = append(t.display, t.editor.text[:caret]...)
+	if t.editing && !t.keyboardMode {
+		comp := t.root.input.TextComposition().Text
+		t.display = append(t.display, []rune(comp)...)
+		caret = len(t.display)
+	}
+	t.display = append(t.display, t.editor.text[t.editor.caret:]...)
+	return t.display, caret
+}
+
+func (t *TextInput) measure(runes []rune) float64 {
+	return float64(font.MeasureString(t.face, string(runes)).Round())
+}
+
+func (t *TextInput) innerWidth() float64 {
+	return t.size.X - 2*t.style.Padding
+}
+
+func (t *TextInput) updateGraphics() {
+	display, caret := t.displayText()
+	innerWidth := t.innerWidth()
+
+	// Scroll the text to make the caret visible.
+	if t.viewStart > caret {
+		t.viewStart = caret
+	}
+	for t.viewStart < caret && t.measure(display[t.viewStart:caret]) > innerWidth {
+		t.viewStart++
+	}
+	for t.viewStart > 0 && t.measure(display[t.viewStart-1:]) <= innerWidth {
+		t.viewStart--
+	}
+	viewEnd := t.viewStart
+	for viewEnd < len(display) && t.measure(display[t.viewStart:viewEnd+1]) <= innerWidth {
+		viewEnd++
+	}
+
+	t.label.Pos = t.Pos.WithOffset(t.style.Padding, 0)
+	t.label.Width = innerWidth
+	t.label.Height = t.size.Y
+	if len(display) == 0 {
+		t.label.Text = t.Placeholder
+		t.label.SetColorScale(t.style.PlaceholderColor)
+	} else {
+		t.label.Text = string(display[t.viewStart:viewEnd])
+		if t.disabled {
+			t.label.SetColorScale(t.style.DisabledTextColor)
+		} else {
+			t.label.SetColorScale(t.style.TextColor)
+		}
+	}
+
+	textHeight := gmath.ClampMax(t.lineHeight, t.size.Y)
+	textY := (t.size.Y - textHeight) / 2
+
+	// The caret blinks with a 1 second period.
+	blink := t.caretTimer - float64(int(t.caretTimer))
+	t.caret.Visible = t.Visible && t.editing && blink < 0.5
+	t.caret.Height = textHeight
+	t.caret.Pos = t.Pos.WithOffset(t.style.Padding+t.measure(display[t.viewStart:caret]), textY)
+
+	t.selection.Visible = false
+	if t.Visible && t.editor.hasSelection() {
+		from, to := t.editor.selection()
+		from = gmath.Clamp(from, t.viewStart, viewEnd)
+		to = gmath.Clamp(to, t.viewStart, viewEnd)
+		if from < to {
+			t.selection.Visible = true
+			t.selection.Pos = t.Pos.WithOffset(t.style.Padding+t.measure(display[t.viewStart:from]), textY)
+			t.selection.Width = t.measure(display[from:to])
+			t.selection.Height = textHeight
+		}
+	}
+}
+
+// posToIndex converts the screen X coordinate to the text char index.
+func (t *TextInput) posToIndex(x float64) int {
+	// The composition is not included: it's replaced when the caret moves.
+	runes := t.editor.text
+	if t.Password {
+		runes = []rune(strings.Repeat(string(t.style.PasswordMask), len(runes)))
+	}
+	x -= t.geom.Min.X + t.style.Padding
+	start := gmath.Clamp(t.viewStart, 0, len(runes))
+	prevWidth := 0.0
+	for i := start; i < len(runes); i++ {
+		width := t.measure(runes[start : i+1])
+		if x < (prevWidth+width)/2 {
+			return i
+		}
+		prevWidth = width
+	}
+	return len(runes)
+}
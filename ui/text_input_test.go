@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/input"
+)
+
+func newTestTextInput(r *Root) *TextInput {
+	t := r.NewTextInput(DefaultTextInputStyle())
+	// A fake background to avoid the Init call.
+	t.bg = &background{rect: &ge.Rect{}}
+	return t
+}
+
+func TestTextInputEditingSwitch(t *testing.T) {
+	var sys input.System
+	h := sys.NewHandler(0, nil)
+	r := NewRoot(&ge.Context{}, h)
+	a := newTestTextInput(r)
+	b := newTestTextInput(r)
+
+	a.startEditing(false)
+	if !a.IsEditing() || !h.TextInputActive() {
+		t.Fatal("the first field should start the text input")
+	}
+
+	// A click on the second field: it starts the editing,
+	// then the first field handles a click outside of it.
+	b.startEditing(false)
+	a.StopEditing()
+	if a.IsEditing() {
+		t.Fatal("the first field should stop the editing")
+	}
+	if !b.IsEditing() || !h.TextInputActive() {
+		t.Fatal("the second field should keep the text input")
+	}
+
+	// The stale owner should not end the session either.
+	a.editing = true
+	a.StopEditing()
+	if !h.TextInputActive() {
+		t.Fatal("the text input is stopped by the field that doesn't own it")
+	}
+
+	b.StopEditing()
+	if b.IsEditing() || h.TextInputActive() {
+		t.Fatal("the text input should be stopped by its owner")
+	}
+	if r.editingInput != nil {
+		t.Fatal("the editing field is not reset")
+	}
+}
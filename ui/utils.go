@@ -1,8 +1,16 @@
 package ui
 
-import "github.com/quasilyte/ge"
+import (
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+)
 
 func withAlpha(c ge.ColorScale, a float32) ge.ColorScale {
 	c.A = a
 	return c
 }
+
+func widgetRect(pos ge.Pos, size gmath.Vec) gmath.Rect {
+	min := pos.Resolve()
+	return gmath.Rect{Min: min, Max: min.Add(size)}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/ge/gesignal"
 	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
 )
 
 type ValueLabel[T comparable] struct {
@@ -24,7 +25,9 @@ type ValueLabel[T comparable] struct {
 	currentValue T
 
 	label *ge.Label
-	rect  *ge.Rect
+	bg    *background
+
+	size gmath.Vec
 
 	root *Root
 }
@@ -40,6 +43,11 @@ type ValueLabelStyle struct {
 	BorderColor     ge.ColorScale
 	BackgroundColor ge.ColorScale
 	TextColor       ge.ColorScale
+
+	// Frame replaces the rect background with a textured frame.
+	// The background color is used as the frame color scale then,
+	// the border settings are ignored.
+	Frame *Frame
 }
 
 func DefaultValueLabelStyle() ValueLabelStyle {
@@ -73,23 +81,22 @@ func NewValueLabel[T comparable](r *Root, style ValueLabelStyle) *ValueLabel[T]
 }
 
 func (l *ValueLabel[T]) Init(scene *ge.Scene) {
-	if l.style.BorderWidth != 0 && l.style.BorderColor.A != 0 {
-		l.rect = ge.NewRect(l.root.ctx, l.style.Width, l.style.Height)
-		l.rect.FillColorScale = l.style.BackgroundColor
-		l.rect.OutlineColorScale = l.style.BorderColor
-		l.rect.OutlineWidth = l.style.BorderWidth
-		l.rect.Pos = l.Pos
-		l.rect.Centered = false
-		scene.AddGraphics(l.rect)
+	if l.size.IsZero() {
+		l.size = l.preferredSize()
+	}
+
+	if l.style.Frame != nil || (l.style.BorderWidth != 0 && l.style.BorderColor.A != 0) {
+		l.bg = newBackground(scene, l.Pos, l.size.X, l.size.Y, l.style.BorderWidth, l.style.Frame, nil)
+		l.bg.SetColors(l.style.BackgroundColor, l.style.BorderColor)
 	}
 
 	l.label = scene.NewLabel(l.style.Font)
-	l.label.Width = l.style.Width
-	l.label.Height = l.style.Height
+	l.label.Width = l.size.X
+	l.label.Height = l.size.Y
 	l.label.AlignHorizontal = ge.AlignHorizontalCenter
 	l.label.AlignVertical = ge.AlignVerticalCenter
 	l.label.Pos = l.Pos
-	l.label.ColorScale = l.style.TextColor
+	l.label.SetColorScale(l.style.TextColor)
 	scene.AddGraphics(l.label)
 
 	if l.value != nil {
@@ -98,11 +105,29 @@ func (l *ValueLabel[T]) Init(scene *ge.Scene) {
 	l.updateText()
 }
 
+func (l *ValueLabel[T]) preferredSize() gmath.Vec {
+	return gmath.Vec{X: l.style.Width, Y: l.style.Height}
+}
+
+func (l *ValueLabel[T]) setLayout(pos ge.Pos, size gmath.Vec) {
+	l.Pos = pos
+	l.size = size
+	if l.label == nil {
+		return // Will be applied during the Init
+	}
+	l.label.Pos = pos
+	l.label.Width = size.X
+	l.label.Height = size.Y
+	if l.bg != nil {
+		l.bg.SetRect(pos, size)
+	}
+}
+
 func (l *ValueLabel[T]) Dispose() {
 	l.eventDisposed.Emit(l)
 	l.label.Dispose()
-	if l.rect != nil {
-		l.rect.Dispose()
+	if l.bg != nil {
+		l.bg.Dispose()
 	}
 }
 
@@ -0,0 +1,122 @@
+package ge
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge/input"
+	"github.com/quasilyte/gmath"
+)
+
+// VirtualControlsStyle describes the on-screen controls appearance.
+//
+// Use DefaultVirtualControlsStyle to get the style with sensible
+// opacity values, then assign the images.
+type VirtualControlsStyle struct {
+	StickBase resource.ImageID
+	StickKnob resource.ImageID
+	DPad      resource.ImageID
+	Button    resource.ImageID
+
+	// ButtonImages can be used to assign a different image
+	// for the buttons bound to the specific keys.
+	// The buttons that are not listed here use the Button image.
+	ButtonImages map[input.Key]resource.ImageID
+
+	// Opacity is an alpha value for the controls that are not touched.
+	Opacity float32
+
+	// ActiveOpacity is an alpha value for the controls that are being touched.
+	ActiveOpacity float32
+}
+
+func DefaultVirtualControlsStyle() VirtualControlsStyle {
+	return VirtualControlsStyle{
+		Opacity:       0.5,
+		ActiveOpacity: 0.9,
+	}
+}
+
+// VirtualControlsView renders the virtual controls state.
+//
+// The view is drawn in screen coordinates, the camera offset is ignored.
+// Add it to the scene using the AddGraphics-like methods.
+type VirtualControlsView struct {
+	Visible bool
+
+	controls *input.VirtualControls
+	style    VirtualControlsStyle
+	ctx      *Context
+
+	stickBases []*Sprite
+	stickKnobs []*Sprite
+	dpads      []*Sprite
+	buttons    []*Sprite
+
+	disposed bool
+}
+
+func NewVirtualControlsView(ctx *Context, controls *input.VirtualControls, style VirtualControlsStyle) *VirtualControlsView {
+	return &VirtualControlsView{
+		Visible:  true,
+		controls: controls,
+		style:    style,
+		ctx:      ctx,
+	}
+}
+
+func (v *VirtualControlsView) IsDisposed() bool { return v.disposed }
+
+func (v *VirtualControlsView) Dispose() { v.disposed = true }
+
+func (v *VirtualControlsView) Draw(screen *ebiten.Image) {
+	if !v.Visible || v.controls.Disabled {
+		return
+	}
+
+	for i, s := range v.controls.Sticks {
+		if i == len(v.stickBases) {
+			v.stickBases = append(v.stickBases, v.newSprite(v.style.StickBase))
+			v.stickKnobs = append(v.stickKnobs, v.newSprite(v.style.StickKnob))
+		}
+		alpha := v.alpha(s.IsActive())
+		v.drawSprite(screen, v.stickBases[i], s.Center(), alpha)
+		v.drawSprite(screen, v.stickKnobs[i], s.KnobPos(), alpha)
+	}
+
+	for i, d := range v.controls.DPads {
+		if i == len(v.dpads) {
+			v.dpads = append(v.dpads, v.newSprite(v.style.DPad))
+		}
+		v.drawSprite(screen, v.dpads[i], d.Pos, v.alpha(d.IsActive()))
+	}
+
+	for i, b := range v.controls.Buttons {
+		if i == len(v.buttons) {
+			imageID, ok := v.style.ButtonImages[b.Key]
+			if !ok {
+				imageID = v.style.Button
+			}
+			v.buttons = append(v.buttons, v.newSprite(imageID))
+		}
+		v.drawSprite(screen, v.buttons[i], b.Pos, v.alpha(b.IsPressed()))
+	}
+}
+
+func (v *VirtualControlsView) alpha(active bool) float32 {
+	if active {
+		return v.style.ActiveOpacity
+	}
+	return v.style.Opacity
+}
+
+func (v *VirtualControlsView) newSprite(imageID resource.ImageID) *Sprite {
+	s := NewSprite(v.ctx)
+	s.SetImage(v.ctx.Loader.LoadImage(imageID))
+	return s
+}
+
+func (v *VirtualControlsView) drawSprite(screen *ebiten.Image, s *Sprite, pos gmath.Vec, alpha float32) {
+	s.Pos.Offset = pos
+	s.SetAlpha(alpha)
+	s.Draw(screen)
+}